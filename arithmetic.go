@@ -1,12 +1,30 @@
 package pipescript
 
-import "math"
+import (
+	"errors"
+	"math"
+)
+
+// Arithmetic propagates nulls: if any operand is null (such as a key missing from an object),
+// the result is null, rather than an error which would stop the entire stream.
+func hasNull(args []*Datapoint) bool {
+	for _, a := range args {
+		if a.Data == nil {
+			return true
+		}
+	}
+	return false
+}
 
 var NegTransform = &Transform{
 	Name:        "neg",
 	Description: "Negation of numbers",
 
 	Constructor: NewBasic(nil, func(dp *Datapoint, args []*Datapoint, consts []interface{}, pipes []*Pipe, out *Datapoint) (*Datapoint, error) {
+		if dp.Data == nil {
+			out.Data = nil
+			return out, nil
+		}
 		b, ok := dp.Data.(bool)
 		if ok {
 			out.Data = !b
@@ -33,6 +51,10 @@ var AddTransform = &Transform{
 		},
 	},
 	Constructor: NewArgBasic(func(args []*Datapoint, consts []interface{}, pipes []*Pipe, out *Datapoint) (*Datapoint, error) {
+		if hasNull(args) {
+			out.Data = nil
+			return out, nil
+		}
 		f1, err := args[0].Float()
 		if err == nil {
 			var f2 float64
@@ -59,6 +81,10 @@ var SubTransform = &Transform{
 		},
 	},
 	Constructor: NewArgBasic(func(args []*Datapoint, consts []interface{}, pipes []*Pipe, out *Datapoint) (*Datapoint, error) {
+		if hasNull(args) {
+			out.Data = nil
+			return out, nil
+		}
 		f1, err := args[0].Float()
 		if err == nil {
 			var f2 float64
@@ -85,6 +111,10 @@ var MulTransform = &Transform{
 		},
 	},
 	Constructor: NewArgBasic(func(args []*Datapoint, consts []interface{}, pipes []*Pipe, out *Datapoint) (*Datapoint, error) {
+		if hasNull(args) {
+			out.Data = nil
+			return out, nil
+		}
 		f1, err := args[0].Float()
 		if err == nil {
 			var f2 float64
@@ -111,6 +141,10 @@ var DivTransform = &Transform{
 		},
 	},
 	Constructor: NewArgBasic(func(args []*Datapoint, consts []interface{}, pipes []*Pipe, out *Datapoint) (*Datapoint, error) {
+		if hasNull(args) {
+			out.Data = nil
+			return out, nil
+		}
 		f1, err := args[0].Float()
 		if err == nil {
 			var f2 float64
@@ -137,12 +171,20 @@ var ModTransform = &Transform{
 		},
 	},
 	Constructor: NewArgBasic(func(args []*Datapoint, consts []interface{}, pipes []*Pipe, out *Datapoint) (*Datapoint, error) {
+		if hasNull(args) {
+			out.Data = nil
+			return out, nil
+		}
 		f1, err := args[0].Int()
 		if err == nil {
 			var f2 int64
 			f2, err = args[1].Int()
-
-			out.Data = f1 % f2
+			if err == nil && f2 == 0 {
+				err = errors.New("modulo by zero")
+			}
+			if err == nil {
+				out.Data = f1 % f2
+			}
 		}
 		return out, err
 	}),
@@ -163,6 +205,10 @@ var PowTransform = &Transform{
 		},
 	},
 	Constructor: NewArgBasic(func(args []*Datapoint, consts []interface{}, pipes []*Pipe, out *Datapoint) (*Datapoint, error) {
+		if hasNull(args) {
+			out.Data = nil
+			return out, nil
+		}
 		f1, err := args[0].Float()
 		if err == nil {
 			var f2 float64
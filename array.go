@@ -0,0 +1,27 @@
+package pipescript
+
+// ArrayTransform builds an array from the values of its args. It is used for array literals such as [d("lat"), d("lon")],
+// and is folded into a constant when all of the elements are constant.
+var ArrayTransform = &Transform{
+	Name:        "array",
+	Description: "Returns an array of the values of its arguments",
+	OutputSchema: map[string]interface{}{
+		"type": "array",
+	},
+	Args: []TransformArg{
+		{
+			Description: "The elements of the array",
+			Type:        TransformArgType,
+			Optional:    true,
+			Variadic:    true,
+		},
+	},
+	Constructor: NewArgBasic(func(args []*Datapoint, consts []interface{}, pipes []*Pipe, out *Datapoint) (*Datapoint, error) {
+		arr := make([]interface{}, len(args))
+		for i := range args {
+			arr[i] = args[i].Data
+		}
+		out.Data = arr
+		return out, nil
+	}),
+}
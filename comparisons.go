@@ -1,5 +1,40 @@
 package pipescript
 
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// Ordering comparisons (<, <=, >, >=) involving null are false, so that filters
+// such as where(d("x") > 5) skip datapoints where x is missing. Equality treats
+// null as a value, so null == null is true.
+
+// compareOrder returns -1, 0 or 1 if a is less than, equal to or greater than b. Two strings are
+// compared alphabetically, and all other values are compared as numbers.
+func compareOrder(a, b *Datapoint) (int, error) {
+	if s1, ok := a.Data.(string); ok {
+		if s2, ok := b.Data.(string); ok {
+			return strings.Compare(s1, s2), nil
+		}
+	}
+	f1, err := a.Float()
+	if err != nil {
+		return 0, err
+	}
+	f2, err := b.Float()
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case f1 < f2:
+		return -1, nil
+	case f1 > f2:
+		return 1, nil
+	}
+	return 0, nil
+}
+
 var LtTransform = &Transform{
 	Name:        "lt",
 	Description: "returns true if the data of the incoming stream is less than the value of the first arg",
@@ -15,13 +50,12 @@ var LtTransform = &Transform{
 		},
 	},
 	Constructor: NewArgBasic(func(args []*Datapoint, consts []interface{}, pipes []*Pipe, out *Datapoint) (*Datapoint, error) {
-		f1, err := args[0].Float()
-		if err == nil {
-			var f2 float64
-			f2, err = args[1].Float()
-
-			out.Data = f1 < f2
+		if hasNull(args) {
+			out.Data = false
+			return out, nil
 		}
+		c, err := compareOrder(args[0], args[1])
+		out.Data = c < 0
 		return out, err
 	}),
 }
@@ -41,13 +75,12 @@ var GtTransform = &Transform{
 		},
 	},
 	Constructor: NewArgBasic(func(args []*Datapoint, consts []interface{}, pipes []*Pipe, out *Datapoint) (*Datapoint, error) {
-		f1, err := args[0].Float()
-		if err == nil {
-			var f2 float64
-			f2, err = args[1].Float()
-
-			out.Data = f1 > f2
+		if hasNull(args) {
+			out.Data = false
+			return out, nil
 		}
+		c, err := compareOrder(args[0], args[1])
+		out.Data = c > 0
 		return out, err
 	}),
 }
@@ -67,13 +100,12 @@ var LteTransform = &Transform{
 		},
 	},
 	Constructor: NewArgBasic(func(args []*Datapoint, consts []interface{}, pipes []*Pipe, out *Datapoint) (*Datapoint, error) {
-		f1, err := args[0].Float()
-		if err == nil {
-			var f2 float64
-			f2, err = args[1].Float()
-
-			out.Data = f1 <= f2
+		if hasNull(args) {
+			out.Data = false
+			return out, nil
 		}
+		c, err := compareOrder(args[0], args[1])
+		out.Data = c <= 0
 		return out, err
 	}),
 }
@@ -93,13 +125,12 @@ var GteTransform = &Transform{
 		},
 	},
 	Constructor: NewArgBasic(func(args []*Datapoint, consts []interface{}, pipes []*Pipe, out *Datapoint) (*Datapoint, error) {
-		f1, err := args[0].Float()
-		if err == nil {
-			var f2 float64
-			f2, err = args[1].Float()
-
-			out.Data = f1 >= f2
+		if hasNull(args) {
+			out.Data = false
+			return out, nil
 		}
+		c, err := compareOrder(args[0], args[1])
+		out.Data = c >= 0
 		return out, err
 	}),
 }
@@ -143,3 +174,118 @@ var NeTransform = &Transform{
 		return out, nil
 	}),
 }
+
+var InTransform = &Transform{
+	Name:        "in",
+	Description: "returns true if the first arg is an element of the array, a key of the object, or a substring of the string given in the second arg",
+
+	Args: []TransformArg{
+		TransformArg{
+			Description: "Value to look for",
+			Type:        TransformArgType,
+		},
+		TransformArg{
+			Description: "The array, object or string to search",
+			Type:        TransformArgType,
+		},
+	},
+	Constructor: NewArgBasic(func(args []*Datapoint, consts []interface{}, pipes []*Pipe, out *Datapoint) (*Datapoint, error) {
+		out.Data = false
+		switch c := args[1].Data.(type) {
+		case nil:
+		case []interface{}:
+			for _, v := range c {
+				if Equal(args[0].Data, v) {
+					out.Data = true
+					break
+				}
+			}
+		case map[string]interface{}:
+			if k, ok := args[0].Data.(string); ok {
+				_, ok = c[k]
+				out.Data = ok
+			}
+		case string:
+			if k, ok := args[0].Data.(string); ok {
+				out.Data = strings.Contains(c, k)
+			}
+		default:
+			return nil, errors.New("in requires an array, object or string to search")
+		}
+		return out, nil
+	}),
+}
+
+var BetweenTransform = &Transform{
+	Name:        "between",
+	Description: "returns true if the first arg is between the second and third args, inclusive",
+
+	Args: []TransformArg{
+		TransformArg{
+			Description: "Value to check",
+			Type:        TransformArgType,
+		},
+		TransformArg{
+			Description: "The lowest allowed value",
+			Type:        TransformArgType,
+		},
+		TransformArg{
+			Description: "The highest allowed value",
+			Type:        TransformArgType,
+		},
+	},
+	Constructor: NewArgBasic(func(args []*Datapoint, consts []interface{}, pipes []*Pipe, out *Datapoint) (*Datapoint, error) {
+		if hasNull(args) {
+			out.Data = false
+			return out, nil
+		}
+		lo, err := compareOrder(args[0], args[1])
+		if err != nil {
+			return nil, err
+		}
+		hi, err := compareOrder(args[0], args[2])
+		out.Data = lo >= 0 && hi <= 0
+		return out, err
+	}),
+}
+
+var MatchTransform = &Transform{
+	Name:        "match",
+	Description: "returns true if the string in the first arg matches the regular expression given in the second arg",
+
+	Args: []TransformArg{
+		TransformArg{
+			Description: "String to check",
+			Type:        TransformArgType,
+		},
+		TransformArg{
+			Description: "The regular expression to match",
+			Type:        TransformArgType,
+			Schema: map[string]interface{}{
+				"type": "string",
+			},
+		},
+	},
+	Constructor: func(transform *Transform, consts []interface{}, pipes []*Pipe) (TransformIterator, error) {
+		// The regex is usually a constant, so the last compiled regex is reused
+		var re *regexp.Regexp
+		return NewArgBasic(func(args []*Datapoint, consts []interface{}, pipes []*Pipe, out *Datapoint) (*Datapoint, error) {
+			out.Data = false
+			pattern, ok := args[1].Data.(string)
+			if !ok {
+				return nil, errors.New("=~ requires a string regular expression")
+			}
+			if re == nil || re.String() != pattern {
+				var err error
+				re, err = regexp.Compile(pattern)
+				if err != nil {
+					return nil, err
+				}
+			}
+			if s, ok := args[0].Data.(string); ok {
+				out.Data = re.MatchString(s)
+			}
+			return out, nil
+		})(transform, consts, pipes)
+	},
+}
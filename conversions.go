@@ -2,7 +2,10 @@ package pipescript
 
 import (
 	"encoding/json"
+	"errors"
+	"reflect"
 	"strconv"
+	"time"
 )
 
 // Int takes an interface that was unmarshalled with the json package,
@@ -143,6 +146,28 @@ func IntNoBool(v interface{}) (int64, bool) {
 	}
 }
 
+// timestampLayouts are the formats accepted by ParseTimestamp. Times without a time zone are in UTC.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// ParseTimestamp parses an RFC3339 time or date (such as "2024-01-01T00:00:00Z" or "2024-01-01"),
+// and returns the corresponding unix timestamp in seconds
+func ParseTimestamp(s string) (float64, error) {
+	for _, layout := range timestampLayouts {
+		t, err := time.Parse(layout, s)
+		if err == nil {
+			return float64(t.UnixNano()) / 1e9, nil
+		}
+	}
+	return 0, errors.New("Could not parse time '" + s + "'. Times must be in RFC3339 format, such as 2024-01-01T00:00:00Z")
+}
+
+// Equal checks if two values are equal. Numbers are equal if they have the same value, no matter their type,
+// and objects and arrays are compared element by element.
 func Equal(o1, o2 interface{}) bool {
 	if o1 == nil || o2 == nil {
 		return o1 == o2
@@ -190,7 +215,8 @@ func Equal(o1, o2 interface{}) bool {
 		}
 		return true
 	default:
-		panic("Unknown interface type for Equal")
+		// Values that did not come from JSON
+		return reflect.DeepEqual(o1, o2)
 	}
 }
 
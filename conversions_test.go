@@ -20,4 +20,9 @@ func TestEqual(t *testing.T) {
 
 	require.True(t, Equal([]interface{}{1, "hi"}, []interface{}{1, "hi"}))
 	require.False(t, Equal([]interface{}{1, "hi"}, []interface{}{1, 3}))
+	require.True(t, Equal([]interface{}{map[string]interface{}{"a": []interface{}{int64(2)}}}, []interface{}{map[string]interface{}{"a": []interface{}{2.0}}}))
+
+	// Values that didn't come from JSON are compared directly
+	require.True(t, Equal([]string{"a", "b"}, []string{"a", "b"}))
+	require.False(t, Equal([]string{"a"}, []interface{}{"a"}))
 }
@@ -80,3 +80,31 @@ func NewDatapointArrayIterator(dpa []Datapoint) *DatapointArrayIterator {
 		Datapoints: dpa,
 	}
 }
+
+// ValueArrayIterator iterates through an array of values, giving each the timestamp and duration
+// of the datapoint that contained the array
+type ValueArrayIterator struct {
+	Timestamp float64
+	Duration  float64
+	Values    []interface{}
+	i         int
+}
+
+func (ai *ValueArrayIterator) Next(out *Datapoint) (*Datapoint, error) {
+	if ai.i >= len(ai.Values) {
+		return nil, nil
+	}
+	out.Timestamp = ai.Timestamp
+	out.Duration = ai.Duration
+	out.Data = ai.Values[ai.i]
+	ai.i++
+	return out, nil
+}
+
+func NewValueArrayIterator(dp *Datapoint, values []interface{}) *ValueArrayIterator {
+	return &ValueArrayIterator{
+		Timestamp: dp.Timestamp,
+		Duration:  dp.Duration,
+		Values:    values,
+	}
+}
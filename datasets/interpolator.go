@@ -59,6 +59,21 @@ func (i *Interpolator) Register() error {
 	return nil
 }
 
+// CheckInterpolator returns an error if the given string is neither the name of a registered interpolator, nor
+// PipeScript that can be used by a TransformInterpolator. This allows checking the interpolator before it is used.
+func CheckInterpolator(interpolator string) error {
+	RegistryLock.RLock()
+	_, ok := InterpolatorRegistry[interpolator]
+	RegistryLock.RUnlock()
+	if ok {
+		return nil
+	}
+	if _, err := pipescript.Parse(interpolator); err != nil {
+		return fmt.Errorf("Unknown interpolator: %w", err)
+	}
+	return nil
+}
+
 // GetInterpolator parses the interpolator given and returns everything initialized. If the given string cannot be
 // parsed as an interpolator, it is assumed to be PipeScript, and a ScriptInterpolator is returned based
 // upon the pipescript. If both these methods fail, returns an error.
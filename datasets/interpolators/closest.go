@@ -54,7 +54,10 @@ var Closest = &datasets.Interpolator{
 	Description: "Returns the datapoint with the closest timestamp to the reference timestamp",
 	Constructor: func(name string, options map[string]interface{}, reference *pipescript.BufferIterator, stream pipescript.Iterator) (pipescript.Iterator, error) {
 		dp1, err := stream.Next(&pipescript.Datapoint{})
-		if err != nil || dp1 == nil {
+		if err != nil {
+			return nil, err
+		}
+		if dp1 == nil {
 			return pipescript.EmptyIterator{}, nil
 		}
 		dp2, err := stream.Next(&pipescript.Datapoint{})
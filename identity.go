@@ -146,4 +146,5 @@ func init() {
 	Identity.Register()
 	T.Register()
 	Dt.Register()
+	ArrayTransform.Register()
 }
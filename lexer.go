@@ -1,46 +1,79 @@
 package pipescript
 
 import (
-	"regexp"
+	"fmt"
+	"strconv"
 	"strings"
 	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
 )
 
-/*
-Hopefully go will let us do this automatically in the future - Joseph
-*/
-
-const (
-	eof           = 0
-	errorString   = "<ERROR>"
-	eofString     = "<EOF>"
-	logicals      = "and|or|not"
-	booleans      = "true|false"
-	numbers       = `[0-9]+(\.[0-9]+)?`
-	comparisons   = `<=|>=|<|>|==|!=`
-	stringregex   = `\"(\\["nrt\\]|.)*?\"|'(\\['nrt\\]|.)*?'`
-	pipes         = `:|\|`
-	brackets      = `\[|\]|\(|\)|{|}`
-	mathoperators = `\-|\*|/|\+|%|\^`
-	idents        = `([a-zA-Z_\$][a-zA-Z_0-9\$]*)`
-	allregex      = logicals + "|" + numbers + "|" + comparisons + "|" + booleans + "|" +
-		stringregex + "|" + pipes + "|" + mathoperators + "|" + idents + "|" + brackets + "|,"
-)
+const eof = -1
 
-var (
-	tokenizer   = regexp.MustCompile(`^(` + allregex + `)`)
-	numberRegex = regexp.MustCompile("^" + numbers + "$")
-	stringRegex = regexp.MustCompile("^" + stringregex + "$")
-	identRegex  = regexp.MustCompile("^" + idents + "$")
-)
+// durationUnits gives the number of seconds in each unit of a duration literal
+var durationUnits = map[string]float64{
+	"ms": 0.001,
+	"s":  1,
+	"m":  60,
+	"h":  60 * 60,
+	"d":  24 * 60 * 60,
+	"w":  7 * 24 * 60 * 60,
+}
+
+// keywords are the identifiers that have a special meaning in the grammar
+var keywords = map[string]int{
+	"and":     pAND,
+	"or":      pOR,
+	"not":     pNOT,
+	"in":      pIN,
+	"between": pBETWEEN,
+	"true":    pBOOL,
+	"false":   pBOOL,
+	"null":    pNULL,
+}
+
+// operators maps the symbols used in PipeScript to their tokens. Two-character operators
+// are checked before single characters.
+var operators = map[string]int{
+	"<=": pCOMPARISON,
+	">=": pCOMPARISON,
+	"==": pCOMPARISON,
+	"!=": pCOMPARISON,
+	"=~": pMATCH,
+	"<":  pCOMPARISON,
+	">":  pCOMPARISON,
+	")":  pRPARENS,
+	"(":  pLPARENS,
+	"]":  pRSQUARE,
+	"[":  pLSQUARE,
+	"}":  pRBRACKET,
+	"{":  pLBRACKET,
+	"|":  pPIPE,
+	":":  pCOLON,
+	",":  pCOMMA,
+	"-":  pMINUS,
+	"+":  pPLUS,
+	"/":  pDIVIDE,
+	"*":  pMULTIPLY,
+	"%":  pMODULO,
+	"^":  pPOW,
+	"=":  pASSIGN,
+}
 
-// parserLex is the parserLexer for transforms
+// parserLex is the parserLexer for transforms. It is a hand-written scanner which
+// reads tokens directly from the input string.
 type parserLex struct {
 	input    string
 	position int
 
 	errorString string
 
+	// depth is the current nesting of brackets, and betweens holds the depth of each "between"
+	// that is waiting for its "and", so that x between 1 and 5 is not read as x between (1 and 5)
+	depth    int
+	betweens []int
+
 	output *Pipe
 }
 
@@ -49,113 +82,365 @@ func (l *parserLex) AtEOF() bool {
 	return l.position >= len(l.input)
 }
 
-// Return the next string for the parserLexer
-func (l *parserLex) Next() string {
-	var c rune = ' '
+// peek returns the rune at the given byte offset from the current position, without consuming it
+func (l *parserLex) peek(offset int) rune {
+	if l.position+offset >= len(l.input) {
+		return eof
+	}
+	r, _ := utf8.DecodeRuneInString(l.input[l.position+offset:])
+	return r
+}
 
-	// skip whitespace
-	for unicode.IsSpace(c) {
-		if l.AtEOF() {
-			return eofString
-		}
-		c = rune(l.input[l.position])
+// next consumes and returns the next rune
+func (l *parserLex) next() rune {
+	if l.AtEOF() {
+		return eof
+	}
+	r, w := utf8.DecodeRuneInString(l.input[l.position:])
+	l.position += w
+	return r
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+func isHexDigit(r rune) bool {
+	return isDigit(r) || (r >= 'a' && r <= 'f') || (r >= 'A' && r <= 'F')
+}
+
+func isIdentStart(r rune) bool {
+	return r == '_' || r == '$' || unicode.IsLetter(r)
+}
+
+func isIdentChar(r rune) bool {
+	return isIdentStart(r) || unicode.IsDigit(r)
+}
+
+// acceptDigits consumes a run of decimal digits, returning the number consumed
+func (l *parserLex) acceptDigits() int {
+	n := 0
+	for isDigit(l.peek(0)) {
 		l.position++
+		n++
+	}
+	return n
+}
+
+// scanDecimal reads a decimal number without an exponent, such as 12, 1.5 or .5
+func (l *parserLex) scanDecimal() bool {
+	n := l.acceptDigits()
+	if l.peek(0) == '.' && isDigit(l.peek(1)) {
+		l.position++
+		n += l.acceptDigits()
+	}
+	return n > 0
+}
+
+// durationUnit returns the duration unit at the current position, or "" if there is none
+func (l *parserLex) durationUnit() string {
+	if l.peek(0) == 'm' && l.peek(1) == 's' {
+		return "ms"
+	}
+	u := string(l.peek(0))
+	if _, ok := durationUnits[u]; ok {
+		return u
+	}
+	return ""
+}
+
+// scanNumber reads a number literal. The value is returned as a string which can be parsed with strconv.ParseFloat.
+// Supported numbers are decimals with optional exponents (1.5e-3, .5), hexadecimal integers (0x1F),
+// and durations (1h30m), which are given in seconds.
+func (l *parserLex) scanNumber() (string, error) {
+	start := l.position
+	if l.peek(0) == '0' && (l.peek(1) == 'x' || l.peek(1) == 'X') {
+		l.position += 2
+		for isHexDigit(l.peek(0)) {
+			l.position++
+		}
+		v, err := strconv.ParseUint(l.input[start+2:l.position], 16, 64)
+		if err != nil {
+			return "", fmt.Errorf("Invalid hexadecimal number '%s'", l.input[start:l.position])
+		}
+		return strconv.FormatUint(v, 10), nil
+	}
+
+	l.scanDecimal()
+	if l.durationUnit() != "" {
+		return l.scanDuration(start)
+	}
+	if r := l.peek(0); r == 'e' || r == 'E' {
+		offset := 1
+		if s := l.peek(1); s == '+' || s == '-' {
+			offset = 2
+		}
+		if isDigit(l.peek(offset)) {
+			l.position += offset
+			l.acceptDigits()
+		}
+	}
+	return l.input[start:l.position], nil
+}
+
+// scanDuration reads a duration literal such as 1h30m, which started at the given position.
+// The first number has already been read.
+func (l *parserLex) scanDuration(start int) (string, error) {
+	l.position = start
+	total := float64(0)
+	for l.scanDecimal() {
+		v, _ := strconv.ParseFloat(l.input[start:l.position], 64)
+		unit := l.durationUnit()
+		if unit == "" {
+			return "", fmt.Errorf("Invalid duration '%s'", l.input[start:l.position])
+		}
+		l.position += len(unit)
+		total += v * durationUnits[unit]
+		start = l.position
+	}
+	if isIdentChar(l.peek(0)) || l.peek(0) == '.' {
+		return "", fmt.Errorf("Invalid duration unit at '%s'", l.input[start:])
+	}
+	return strconv.FormatFloat(total, 'g', -1, 64), nil
+}
+
+// acceptTemplate consumes input matching the template, where 'd' matches any digit, and all other characters
+// match themselves. Nothing is consumed if the input doesn't match.
+func (l *parserLex) acceptTemplate(template string) bool {
+	if l.position+len(template) > len(l.input) {
+		return false
+	}
+	for i := range template {
+		c := l.input[l.position+i]
+		if template[i] == 'd' {
+			if !isDigit(rune(c)) {
+				return false
+			}
+		} else if template[i] != c {
+			return false
+		}
 	}
+	l.position += len(template)
+	return true
+}
 
-	l.position--
+// scanTime reads a time literal such as @2024-01-01T00:00:00Z, returning the corresponding unix timestamp
+func (l *parserLex) scanTime() (string, error) {
+	l.position++ // skip the @
+	start := l.position
+	if !l.acceptTemplate("dddd-dd-dd") {
+		return "", fmt.Errorf("Invalid time at '%s'. Times must be in RFC3339 format, such as @2024-01-01T00:00:00Z", l.input[start-1:])
+	}
+	if l.acceptTemplate("Tdd:dd") {
+		if l.acceptTemplate(":dd") && l.acceptTemplate(".d") {
+			l.acceptDigits()
+		}
+		if !l.acceptTemplate("Z") && !l.acceptTemplate("+dd:dd") {
+			l.acceptTemplate("-dd:dd")
+		}
+	}
+	ts, err := ParseTimestamp(l.input[start:l.position])
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatFloat(ts, 'g', -1, 64), nil
+}
+
+// scanHex reads the 4 hex digits of a \u escape
+func (l *parserLex) scanHex() (rune, bool) {
+	if l.position+4 > len(l.input) {
+		return 0, false
+	}
+	v, err := strconv.ParseUint(l.input[l.position:l.position+4], 16, 32)
+	if err != nil {
+		return 0, false
+	}
+	l.position += 4
+	return rune(v), true
+}
 
-	rest := l.input[l.position:]
+// scanString reads a string literal in single or double quotes. JSON escape sequences are supported,
+// including \uXXXX with surrogate pairs. Unrecognized escapes are kept as written, so that
+// regular expressions such as '\d+' don't need their backslashes doubled.
+func (l *parserLex) scanString() (string, error) {
+	start := l.position
+	quote := l.next()
+	var b strings.Builder
+	for {
+		r := l.next()
+		switch r {
+		case eof:
+			return "", fmt.Errorf("Unterminated string %s", l.input[start:])
+		case quote:
+			return b.String(), nil
+		case '\\':
+			e := l.next()
+			switch e {
+			case 'n':
+				b.WriteRune('\n')
+			case 'r':
+				b.WriteRune('\r')
+			case 't':
+				b.WriteRune('\t')
+			case 'b':
+				b.WriteRune('\b')
+			case 'f':
+				b.WriteRune('\f')
+			case '\\', '/', '"', '\'':
+				b.WriteRune(e)
+			case 'u':
+				u, ok := l.scanHex()
+				if !ok {
+					return "", fmt.Errorf("Invalid unicode escape in string %s", l.input[start:])
+				}
+				if utf16.IsSurrogate(u) && l.acceptTemplate("\\u") {
+					u2, ok := l.scanHex()
+					if !ok {
+						return "", fmt.Errorf("Invalid unicode escape in string %s", l.input[start:])
+					}
+					u = utf16.DecodeRune(u, u2)
+				}
+				b.WriteRune(u)
+			case eof:
+				return "", fmt.Errorf("Unterminated string %s", l.input[start:])
+			default:
+				b.WriteRune('\\')
+				b.WriteRune(e)
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+}
 
-	token := tokenizer.FindString(rest)
-	l.position += len(token)
+// acceptKeyword consumes the given keyword if it comes next after whitespace
+func (l *parserLex) acceptKeyword(word string) bool {
+	i := 0
+	for unicode.IsSpace(l.peek(i)) {
+		i++
+	}
+	if !strings.HasPrefix(l.input[l.position+i:], word) || isIdentChar(l.peek(i+len(word))) {
+		return false
+	}
+	l.position += i + len(word)
+	return true
+}
 
-	if token == "" {
-		return errorString
+// followedByMinus returns whether the next character after whitespace is a minus
+func (l *parserLex) followedByMinus() bool {
+	i := 0
+	for unicode.IsSpace(l.peek(i)) {
+		i++
 	}
+	return l.peek(i) == '-'
+}
 
-	return token
+// keyword returns the token of a keyword, keeping track of the "and" which ends each "between".
+// A "not" followed by "in" or "between" is a single token, so that x not in y is not read as x(not (in y)).
+func (l *parserLex) keyword(tok int, lval *parserSymType) int {
+	switch tok {
+	case pNOT:
+		if l.acceptKeyword("in") {
+			lval.strVal = "not in"
+			return pNOT_IN
+		}
+		if l.acceptKeyword("between") {
+			lval.strVal = "not between"
+			l.betweens = append(l.betweens, l.depth)
+			return pNOT_BETWEEN
+		}
+	case pBETWEEN:
+		l.betweens = append(l.betweens, l.depth)
+	case pAND:
+		if n := len(l.betweens); n > 0 && l.betweens[n-1] == l.depth {
+			l.betweens = l.betweens[:n-1]
+			return pBETWEEN_AND
+		}
+	}
+	return tok
+}
+
+// bracket keeps track of the nesting depth of brackets
+func (l *parserLex) bracket(tok int) int {
+	switch tok {
+	case pLPARENS, pLSQUARE, pLBRACKET:
+		l.depth++
+	case pRPARENS, pRSQUARE, pRBRACKET:
+		l.depth--
+		// A between that was not given its "and" inside the brackets is a syntax error,
+		// which the parser will catch
+		for n := len(l.betweens); n > 0 && l.betweens[n-1] > l.depth; n-- {
+			l.betweens = l.betweens[:n-1]
+		}
+	}
+	return tok
 }
 
 func (l *parserLex) Error(s string) {
-	l.errorString = s
+	// The first error is kept, since errors found by the scanner are more descriptive than
+	// the parser's resulting syntax error
+	if l.errorString == "" {
+		l.errorString = s
+	}
 }
 
 func (l *parserLex) Lex(lval *parserSymType) int {
-	token := l.Next()
-	lval.strVal = token
-	switch token {
-	case eofString:
-		return 0
-	case errorString:
-		l.Error("Error, unknown token")
+	// skip whitespace
+	for unicode.IsSpace(l.peek(0)) {
+		l.next()
+	}
+	if l.AtEOF() {
 		return 0
-	case ")":
-		return pRPARENS
-	case "(":
-		return pLPARENS
-	case "]":
-		return pRSQUARE
-	case "[":
-		return pLSQUARE
-	case "{":
-		return pLBRACKET
-	case "}":
-		return pRBRACKET
-	case "and":
-		return pAND
-	case "or":
-		return pOR
-	case "not":
-		return pNOT
-	case ">=", "<=", ">", "<", "==", "!=":
-		return pCOMPARISON
-	case "true", "false":
-		return pBOOL
-	case "|":
-		return pPIPE
-	case ":":
-		return pCOLON
-	case ",":
-		return pCOMMA
-	case "-":
-		return pMINUS
-	case "+":
-		return pPLUS
-	case "/":
-		return pDIVIDE
-	case "*":
-		return pMULTIPLY
-	case "%":
-		return pMODULO
-	case "^":
-		return pPOW
-	default:
-		switch {
-		case numberRegex.MatchString(token):
-			return pNUMBER
-
-		case stringRegex.MatchString(token):
-			// unquote token
-			strval := token[1 : len(token)-1]
-
-			// replace escape characters
-			strval = strings.Replace(strval, "\\n", "\n", -1)
-			strval = strings.Replace(strval, "\\r", "\r", -1)
-			strval = strings.Replace(strval, "\\t", "\t", -1)
-			strval = strings.Replace(strval, "\\\\", "\\", -1)
-			strval = strings.Replace(strval, "\\\"", "\"", -1)
-			strval = strings.Replace(strval, "\\'", "'", -1)
+	}
+	start := l.position
+	r := l.peek(0)
 
-			lval.strVal = strval
+	var err error
+	switch {
+	case isDigit(r) || (r == '.' && isDigit(l.peek(1))):
+		lval.strVal, err = l.scanNumber()
+		if err == nil {
+			return pNUMBER
+		}
+	case r == '@':
+		lval.strVal, err = l.scanTime()
+		if err == nil {
+			return pNUMBER
+		}
+	case r == '"' || r == '\'':
+		lval.strVal, err = l.scanString()
+		if err == nil {
 			return pSTRING
-
-		default:
-			// If there is a space after the identifier, it isn't being called as f(x)
-			if l.position < len(l.input) && unicode.IsSpace(rune(l.input[l.position])) {
-				return pIDENTIFIER_SPACE
+		}
+	case isIdentStart(r):
+		for isIdentChar(l.peek(0)) {
+			l.next()
+		}
+		lval.strVal = l.input[start:l.position]
+		if tok, ok := keywords[lval.strVal]; ok {
+			return l.keyword(tok, lval)
+		}
+		// If there is a space after the identifier, it isn't being called as f(x). A minus after the space
+		// is a subtraction rather than a negative arg, so x - 1 is not read as x(-1).
+		if unicode.IsSpace(l.peek(0)) && !l.followedByMinus() {
+			return pIDENTIFIER_SPACE
+		}
+		return pIDENTIFIER
+	default:
+		if l.position+2 <= len(l.input) {
+			if tok, ok := operators[l.input[l.position:l.position+2]]; ok {
+				l.position += 2
+				lval.strVal = l.input[start:l.position]
+				return tok
 			}
-			return pIDENTIFIER
-
 		}
+		if tok, ok := operators[string(r)]; ok {
+			l.next()
+			lval.strVal = l.input[start:l.position]
+			return l.bracket(tok)
+		}
+		err = fmt.Errorf("Unknown token at '%s'", l.input[start:])
 	}
+	l.Error(err.Error())
+	return 0
 }
@@ -0,0 +1,59 @@
+//go:build go1.18
+// +build go1.18
+
+package pipescript
+
+import (
+	"math"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
+)
+
+// FuzzConstRoundTrip checks that constant strings and numbers are scanned back to the same value
+// from the output of Pipe.String
+func FuzzConstRoundTrip(f *testing.F) {
+	f.Add("hello", 1.5)
+	f.Add("a\"b'c\\d", -3.0)
+	f.Add("<é>& \t", 1e-7)
+	f.Add("😀\x00", 1e300)
+	f.Fuzz(func(t *testing.T, s string, x float64) {
+		values := []interface{}{}
+		// json.Marshal replaces invalid UTF-8, so such strings can't round trip
+		if utf8.ValidString(s) {
+			values = append(values, s)
+		}
+		if !math.IsNaN(x) && !math.IsInf(x, 0) {
+			values = append(values, x)
+		}
+		for _, v := range values {
+			p := MustPipe(NewConstTransform(v), nil)
+			p2, err := Parse(p.String())
+			require.NoError(t, err, p.String())
+			c, err := p2.GetConst()
+			require.NoError(t, err, p.String())
+			require.Equal(t, v, c, p.String())
+			require.Equal(t, p.String(), p2.String())
+		}
+	})
+}
+
+// FuzzParse checks that arbitrary input never causes the scanner or parser to panic, and that scripts
+// which parse are printed by Pipe.String as a script that parses to the same pipe
+func FuzzParse(f *testing.F) {
+	f.Add("d('a') > 1.5e3 and not $x")
+	f.Add("'\\ud83d\\ude00' + @2024-01-01T00:00:00Z - 1h30m")
+	f.Add("{\"größe\": 0x1F, 'b': [.5, null]}")
+	f.Add("-(d:t) ^ 2 % 3 + not d not between 1 and 2 or d =~ 'a' and $x:d not in [1/0, 'b']")
+	f.Add("{'b\"': [d(1), -1e-300], 'a': {'c': t}}")
+	f.Fuzz(func(t *testing.T, script string) {
+		p, err := Parse(script)
+		if err != nil {
+			return
+		}
+		p2, err := Parse(p.String())
+		require.NoError(t, err, p.String())
+		require.Equal(t, p.String(), p2.String(), script)
+	})
+}
@@ -0,0 +1,58 @@
+package pipescript
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLexer(t *testing.T) {
+	lex := func(script string) (tokens []int, values []string) {
+		l := parserLex{input: script}
+		var lval parserSymType
+		for tok := l.Lex(&lval); tok != 0; tok = l.Lex(&lval) {
+			tokens = append(tokens, tok)
+			values = append(values, lval.strVal)
+		}
+		require.Empty(t, l.errorString, script)
+		return
+	}
+
+	tokens, values := lex("größe(ÄÖ_1) >= 1.5e3 and $x")
+	require.Equal(t, []int{pIDENTIFIER, pLPARENS, pIDENTIFIER, pRPARENS, pCOMPARISON, pNUMBER, pAND, pIDENTIFIER}, tokens)
+	require.Equal(t, []string{"größe", "(", "ÄÖ_1", ")", ">=", "1.5e3", "and", "$x"}, values)
+
+	// Keywords must be whole identifiers
+	tokens, values = lex("order nothing null")
+	require.Equal(t, []int{pIDENTIFIER_SPACE, pIDENTIFIER_SPACE, pNULL}, tokens)
+	require.Equal(t, []string{"order", "nothing", "null"}, values)
+
+	// The "and" of a between is its own token, unless it is inside brackets
+	tokens, _ = lex("x between (a and b) and c and d =~ e")
+	require.Equal(t, []int{pIDENTIFIER_SPACE, pBETWEEN, pLPARENS, pIDENTIFIER_SPACE, pAND, pIDENTIFIER, pRPARENS, pBETWEEN_AND, pIDENTIFIER_SPACE, pAND, pIDENTIFIER_SPACE, pMATCH, pIDENTIFIER}, tokens)
+
+	// A "not" followed by "in" or "between" is a single token
+	tokens, values = lex("x not in y not  between 1 and 2 not inside")
+	require.Equal(t, []int{pIDENTIFIER_SPACE, pNOT_IN, pIDENTIFIER_SPACE, pNOT_BETWEEN, pNUMBER, pBETWEEN_AND, pNUMBER, pNOT, pIDENTIFIER}, tokens)
+	require.Equal(t, []string{"x", "not in", "y", "not between", "1", "and", "2", "not", "inside"}, values)
+
+	// An identifier followed by a minus is subtracted from, rather than given a negative arg
+	tokens, _ = lex("x - 1 y -2 z 3")
+	require.Equal(t, []int{pIDENTIFIER, pMINUS, pNUMBER, pIDENTIFIER, pMINUS, pNUMBER, pIDENTIFIER_SPACE, pNUMBER}, tokens)
+
+	// An e that isn't followed by an exponent is not part of the number
+	tokens, values = lex("2e")
+	require.Equal(t, []int{pNUMBER, pIDENTIFIER}, tokens)
+	require.Equal(t, []string{"2", "e"}, values)
+}
+
+func TestConstRoundTrip(t *testing.T) {
+	for _, v := range []interface{}{[]interface{}{"a", 1.5, nil, []interface{}{}}, "a<b>&c", " \x00\x1f", "'\"\\", "日本😀", 1e-7, 1e300, -0.25, float64(12)} {
+		p := MustPipe(NewConstTransform(v), nil)
+		p2, err := Parse(p.String())
+		require.NoError(t, err, p.String())
+		c, err := p2.GetConst()
+		require.NoError(t, err, p.String())
+		require.Equal(t, v, c, p.String())
+	}
+}
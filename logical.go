@@ -1,11 +1,19 @@
 package pipescript
 
+// In logical operations, null is treated as false.
+func nullBool(dp *Datapoint) (bool, error) {
+	if dp.Data == nil {
+		return false, nil
+	}
+	return dp.Bool()
+}
+
 var NotTransform = &Transform{
 	Name:        "not",
 	Description: "Boolean not",
 
 	Constructor: NewBasic(nil, func(dp *Datapoint, args []*Datapoint, consts []interface{}, pipes []*Pipe, out *Datapoint) (*Datapoint, error) {
-		b, err := dp.Bool()
+		b, err := nullBool(dp)
 		out.Data = !b
 		return out, err
 	}),
@@ -26,10 +34,10 @@ var AndTransform = &Transform{
 		},
 	},
 	Constructor: NewArgBasic(func(args []*Datapoint, consts []interface{}, pipes []*Pipe, out *Datapoint) (*Datapoint, error) {
-		f1, err := args[0].Bool()
+		f1, err := nullBool(args[0])
 		if err == nil {
 			var f2 bool
-			f2, err = args[1].Bool()
+			f2, err = nullBool(args[1])
 
 			out.Data = f1 && f2
 		}
@@ -52,10 +60,10 @@ var OrTransform = &Transform{
 		},
 	},
 	Constructor: NewArgBasic(func(args []*Datapoint, consts []interface{}, pipes []*Pipe, out *Datapoint) (*Datapoint, error) {
-		f1, err := args[0].Bool()
+		f1, err := nullBool(args[0])
 		if err == nil {
 			var f2 bool
-			f2, err = args[1].Bool()
+			f2, err = nullBool(args[1])
 
 			out.Data = f1 || f2
 		}
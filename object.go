@@ -3,6 +3,7 @@ package pipescript
 import (
 	"container/list"
 	"fmt"
+	"sort"
 )
 
 type aggregatePipeContext struct {
@@ -11,6 +12,7 @@ type aggregatePipeContext struct {
 }
 
 type aggregateObjectTransform struct {
+	pipes  map[string]*Pipe // The pipes of the object, which are started on the first call to Next
 	obj    map[string]*aggregatePipeContext
 	data   map[string]interface{}
 	isDone bool
@@ -31,6 +33,14 @@ func (a *aggregateObjectTransform) Next(e *TransformEnv, out *Datapoint) (*Datap
 
 		return nil, nil
 	}
+	if a.obj == nil {
+		a.obj = make(map[string]*aggregatePipeContext)
+		for k, p := range a.pipes {
+			a.obj[k] = &aggregatePipeContext{
+				cp: NewChannelPipe(p),
+			}
+		}
+	}
 	defer a.Close()
 	dp, _, err := e.Next(nil)
 	if err != nil || dp == nil {
@@ -95,6 +105,7 @@ type oneToOneObjectContext struct {
 }
 
 type oneToOneObjectTransform struct {
+	pipes    map[string]*Pipe // The pipes of the object, which are started on the first call to Next
 	obj      map[string]*oneToOneObjectContext
 	isDone   bool
 	dataDone bool
@@ -115,6 +126,15 @@ func (o *oneToOneObjectTransform) Next(e *TransformEnv, out *Datapoint) (*Datapo
 	if o.isDone {
 		return nil, nil
 	}
+	if o.obj == nil {
+		o.obj = make(map[string]*oneToOneObjectContext)
+		for k, p := range o.pipes {
+			o.obj[k] = &oneToOneObjectContext{
+				cp:    NewChannelPipe(p),
+				recvd: list.New(),
+			}
+		}
+	}
 
 	// Check if we have a result already waiting in the list
 	hasNext := false
@@ -235,9 +255,14 @@ func NewObjectTransform(obj map[string]*Pipe) *Transform {
 			break
 		}
 	}
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
 	oname := "{"
-	for k, v := range obj {
-		oname += fmt.Sprintf("\"%s\": %s,", k, v.String())
+	for _, k := range keys {
+		oname += fmt.Sprintf("%s: %s,", constString(k), obj[k].String())
 	}
 	oname = oname[:len(oname)-1] + "}"
 
@@ -245,16 +270,13 @@ func NewObjectTransform(obj map[string]*Pipe) *Transform {
 		return &Transform{
 			Name: oname,
 			Constructor: func(transform *Transform, consts []interface{}, pipes []*Pipe) (TransformIterator, error) {
-				ooc := make(map[string]*oneToOneObjectContext)
+				op := make(map[string]*Pipe)
 				for k, p := range obj {
-					ooc[k] = &oneToOneObjectContext{
-						cp:    NewChannelPipe(p.Copy()),
-						recvd: list.New(),
-					}
+					op[k] = p.Copy()
 				}
 
 				return &oneToOneObjectTransform{
-					obj: ooc,
+					pipes: op,
 				}, nil
 			},
 		}
@@ -263,18 +285,16 @@ func NewObjectTransform(obj map[string]*Pipe) *Transform {
 		Name: oname,
 		Constructor: func(transform *Transform, consts []interface{}, pipes []*Pipe) (TransformIterator, error) {
 
-			apc := make(map[string]*aggregatePipeContext)
+			op := make(map[string]*Pipe)
 			vals := make(map[string]interface{})
 			for k, p := range obj {
-				apc[k] = &aggregatePipeContext{
-					cp: NewChannelPipe(p.Copy()),
-				}
+				op[k] = p.Copy()
 				vals[k] = nil
 			}
 
 			return &aggregateObjectTransform{
-				obj:  apc,
-				data: vals,
+				pipes: op,
+				data:  vals,
 			}, nil
 		},
 	}
@@ -17,9 +17,10 @@ import (
 type scriptFunc struct {
 	transform string
 	args      []*Pipe
+	kwargs    map[string]*Pipe
 }
 
-//line parser.y:24
+//line parser.y:25
 type parserSymType struct {
 	yys         int
 	script      *Pipe
@@ -32,30 +33,38 @@ type parserSymType struct {
 const pNUMBER = 57346
 const pSTRING = 57347
 const pBOOL = 57348
-const pIDENTIFIER = 57349
-const pIDENTIFIER_SPACE = 57350
-const pAND = 57351
-const pOR = 57352
-const pNOT = 57353
-const pCOMPARISON = 57354
-const pPLUS = 57355
-const pMINUS = 57356
-const pMULTIPLY = 57357
-const pDIVIDE = 57358
-const pMODULO = 57359
-const pPOW = 57360
-const pCOMMA = 57361
-const pRPARENS = 57362
-const pLPARENS = 57363
-const pRSQUARE = 57364
-const pLSQUARE = 57365
-const pRBRACKET = 57366
-const pLBRACKET = 57367
-const pPIPE = 57368
-const pCOLON = 57369
-const pNOARGS = 57370
-const pARGS = 57371
-const pUMINUS = 57372
+const pNULL = 57349
+const pIDENTIFIER = 57350
+const pIDENTIFIER_SPACE = 57351
+const pAND = 57352
+const pOR = 57353
+const pNOT = 57354
+const pCOMPARISON = 57355
+const pPLUS = 57356
+const pMINUS = 57357
+const pMULTIPLY = 57358
+const pDIVIDE = 57359
+const pMODULO = 57360
+const pPOW = 57361
+const pCOMMA = 57362
+const pRPARENS = 57363
+const pLPARENS = 57364
+const pRSQUARE = 57365
+const pLSQUARE = 57366
+const pRBRACKET = 57367
+const pLBRACKET = 57368
+const pPIPE = 57369
+const pCOLON = 57370
+const pASSIGN = 57371
+const pIN = 57372
+const pNOT_IN = 57373
+const pBETWEEN = 57374
+const pNOT_BETWEEN = 57375
+const pBETWEEN_AND = 57376
+const pMATCH = 57377
+const pNOARGS = 57378
+const pARGS = 57379
+const pUMINUS = 57380
 
 var parserToknames = [...]string{
 	"$end",
@@ -64,6 +73,7 @@ var parserToknames = [...]string{
 	"pNUMBER",
 	"pSTRING",
 	"pBOOL",
+	"pNULL",
 	"pIDENTIFIER",
 	"pIDENTIFIER_SPACE",
 	"pAND",
@@ -85,19 +95,37 @@ var parserToknames = [...]string{
 	"pLBRACKET",
 	"pPIPE",
 	"pCOLON",
+	"pASSIGN",
+	"pIN",
+	"pNOT_IN",
+	"pBETWEEN",
+	"pNOT_BETWEEN",
+	"pBETWEEN_AND",
+	"pMATCH",
 	"pNOARGS",
 	"pARGS",
 	"pUMINUS",
 }
+
 var parserStatenames = [...]string{}
 
 const parserEofCode = 1
 const parserErrCode = 2
 const parserInitialStackSize = 16
 
-//line parser.y:446
+//line parser.y:595
 
 func parserGetScript(sf scriptFunc) (*Pipe, error) {
+	if len(sf.transform) > 1 && sf.transform[0] == '$' {
+		// $name refers to a named input stream
+		if len(sf.args) > 0 || len(sf.kwargs) > 0 {
+			return nil, fmt.Errorf("The input stream '%s' does not take arguments", sf.transform)
+		}
+		return NewElementPipe(NewStreamTransform(sf.transform[1:]), nil)
+	}
+	if len(sf.kwargs) > 0 {
+		return NewKeywordTransformPipe(sf.transform, sf.args, sf.kwargs)
+	}
 	return NewTransformPipe(sf.transform, sf.args)
 }
 
@@ -196,113 +224,143 @@ var parserExca = [...]int{
 	-1, 1,
 	1, -1,
 	-2, 0,
-	-1, 56,
-	1, 19,
-	9, 19,
-	10, 19,
-	12, 19,
-	13, 19,
-	14, 19,
-	20, 19,
-	22, 19,
-	26, 19,
-	-2, 21,
+	-1, 79,
+	29, 51,
+	-2, 47,
+	-1, 80,
+	29, 52,
+	-2, 48,
 }
 
 const parserPrivate = 57344
 
-const parserLast = 229
+const parserLast = 405
 
 var parserAct = [...]int{
-
-	4, 60, 29, 30, 27, 28, 33, 23, 35, 36,
-	38, 27, 28, 59, 23, 57, 58, 22, 22, 3,
-	68, 23, 22, 71, 45, 46, 47, 48, 49, 50,
-	51, 52, 53, 54, 42, 55, 43, 39, 56, 68,
-	67, 2, 44, 61, 64, 63, 25, 26, 14, 24,
-	31, 32, 29, 30, 27, 28, 65, 13, 66, 5,
-	40, 41, 12, 6, 23, 10, 11, 1, 0, 75,
-	0, 76, 25, 26, 0, 24, 31, 32, 29, 30,
-	27, 28, 74, 0, 0, 0, 0, 73, 25, 26,
-	23, 24, 31, 32, 29, 30, 27, 28, 70, 0,
-	0, 72, 0, 0, 25, 26, 23, 24, 31, 32,
-	29, 30, 27, 28, 70, 69, 0, 0, 0, 0,
-	25, 0, 23, 24, 31, 32, 29, 30, 27, 28,
-	15, 16, 17, 20, 34, 0, 0, 7, 23, 0,
-	9, 0, 0, 0, 0, 0, 62, 18, 0, 19,
-	0, 21, 15, 16, 17, 20, 34, 0, 0, 7,
-	0, 0, 9, 0, 0, 0, 0, 0, 0, 18,
-	0, 19, 0, 21, 24, 31, 32, 29, 30, 27,
-	28, 15, 16, 17, 20, 8, 0, 0, 7, 23,
-	0, 9, 0, 0, 0, 0, 0, 0, 18, 0,
-	19, 0, 21, 15, 16, 17, 20, 34, 0, 0,
-	7, 0, 0, 37, 31, 32, 29, 30, 27, 28,
-	18, 0, 19, 0, 21, 0, 0, 0, 23,
+	4, 75, 113, 49, 78, 95, 40, 25, 42, 43,
+	44, 38, 39, 36, 37, 34, 35, 69, 68, 24,
+	92, 114, 48, 24, 3, 25, 53, 54, 55, 56,
+	57, 58, 59, 60, 61, 62, 63, 64, 65, 66,
+	67, 50, 15, 51, 36, 37, 34, 35, 45, 52,
+	14, 76, 82, 83, 74, 81, 25, 92, 32, 33,
+	116, 26, 38, 39, 36, 37, 34, 35, 104, 86,
+	92, 112, 87, 103, 88, 5, 25, 13, 27, 28,
+	29, 30, 12, 31, 92, 101, 102, 115, 92, 91,
+	6, 88, 105, 107, 87, 108, 109, 106, 88, 110,
+	87, 111, 32, 33, 10, 26, 38, 39, 36, 37,
+	34, 35, 99, 11, 117, 98, 34, 35, 1, 0,
+	25, 0, 27, 28, 29, 30, 25, 31, 32, 33,
+	0, 26, 38, 39, 36, 37, 34, 35, 93, 94,
+	92, 97, 73, 100, 96, 72, 25, 2, 27, 28,
+	29, 30, 0, 31, 32, 33, 0, 26, 38, 39,
+	36, 37, 34, 35, 71, 90, 89, 70, 46, 0,
+	0, 0, 25, 0, 27, 28, 29, 30, 0, 31,
+	32, 33, 0, 26, 38, 39, 36, 37, 34, 35,
+	0, 0, 0, 0, 0, 0, 0, 0, 25, 0,
+	27, 28, 29, 30, 85, 31, 32, 33, 0, 26,
+	38, 39, 36, 37, 34, 35, 0, 0, 0, 0,
+	0, 0, 0, 0, 25, 0, 27, 28, 29, 30,
+	84, 31, 32, 33, 0, 26, 38, 39, 36, 37,
+	34, 35, 0, 0, 0, 0, 0, 0, 0, 0,
+	25, 0, 27, 28, 29, 30, 32, 31, 0, 26,
+	38, 39, 36, 37, 34, 35, 0, 26, 38, 39,
+	36, 37, 34, 35, 25, 0, 27, 28, 29, 30,
+	0, 31, 25, 0, 27, 28, 29, 30, 0, 31,
+	16, 17, 18, 19, 79, 80, 0, 0, 7, 0,
+	0, 8, 0, 0, 0, 0, 0, 77, 20, 0,
+	21, 0, 23, 16, 17, 18, 19, 22, 41, 0,
+	0, 7, 0, 0, 8, 0, 0, 0, 0, 0,
+	0, 20, 47, 21, 0, 23, 16, 17, 18, 19,
+	22, 41, 0, 0, 7, 0, 0, 8, 0, 0,
+	0, 0, 0, 0, 20, 0, 21, 0, 23, 16,
+	17, 18, 19, 79, 80, 0, 0, 7, 0, 0,
+	8, 0, 0, 0, 0, 0, 0, 20, 0, 21,
+	0, 23, 16, 17, 18, 19, 22, 9, 0, 0,
+	7, 0, 0, 8, 0, 0, 0, 0, 0, 0,
+	20, 0, 21, 0, 23,
 }
-var parserPact = [...]int{
 
-	177, -1000, -8, -1000, 37, 148, -1000, 148, 199, 148,
-	-1000, -1000, -1000, -1000, 32, -1000, -1000, -1000, 177, 177,
-	13, -1000, 177, 148, 148, 148, 148, 148, 148, 148,
-	148, 148, 148, 37, 21, 162, 37, 148, -20, -12,
-	-4, -9, 126, 148, -1000, -1000, 201, 162, 111, -20,
-	-20, -6, -6, -13, -13, 148, -20, 148, -1000, -1000,
-	20, 95, -1000, 1, 79, -13, 63, -1000, 148, -1000,
-	148, -1000, -1000, -1000, -1000, 37, 37,
+var parserPact = [...]int{
+	378, -1000, -8, -1000, 222, 332, -1000, 332, 332, 332,
+	-1000, -1000, -1000, -1000, -1000, 43, -1000, -1000, -1000, -1000,
+	378, 309, 19, -1000, 378, 332, 332, 332, 332, 332,
+	332, 332, 332, 332, 332, 332, 332, 332, 332, 332,
+	222, -1000, 254, -21, 222, -10, -4, -1000, 144, 122,
+	286, 355, -1000, -1000, -3, -3, -3, 196, 170, -3,
+	254, 246, -21, -21, 98, 98, 28, 28, 332, -1000,
+	-1000, 332, -1000, 332, 145, 68, 118, -1000, -24, 19,
+	-1000, 121, 92, 120, 332, 332, 48, 222, 222, -1000,
+	355, -1000, 355, 355, -1000, 332, -1000, 355, -1000, 355,
+	-1000, -3, -3, -1000, -1000, 50, -27, 222, 0, 222,
+	64, 37, -1000, 332, -1000, -1000, -1000, 222,
 }
-var parserPgo = [...]int{
 
-	0, 67, 41, 66, 0, 65, 19, 63, 62, 59,
-	57, 1, 48,
+var parserPgo = [...]int{
+	0, 118, 147, 113, 0, 104, 24, 90, 82, 77,
+	75, 50, 3, 42, 1, 4,
 }
-var parserR1 = [...]int{
 
-	0, 1, 2, 2, 6, 6, 9, 9, 4, 4,
+var parserR1 = [...]int{
+	0, 1, 2, 2, 6, 6, 10, 10, 4, 4,
 	4, 4, 4, 4, 4, 4, 4, 4, 4, 4,
-	4, 4, 7, 7, 7, 8, 8, 5, 5, 10,
-	10, 10, 10, 10, 10, 10, 11, 11, 12, 12,
-	3, 3, 3,
+	4, 4, 4, 4, 4, 4, 7, 7, 7, 7,
+	8, 9, 9, 9, 5, 5, 11, 11, 11, 11,
+	11, 11, 11, 11, 11, 11, 11, 11, 11, 12,
+	12, 15, 15, 14, 14, 14, 13, 13, 3, 3,
+	3, 3,
 }
-var parserR2 = [...]int{
 
+var parserR2 = [...]int{
 	0, 1, 1, 3, 1, 1, 2, 2, 1, 3,
-	2, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 2, 1, 1, 1, 3, 3, 1, 5, 4,
-	4, 4, 4, 3, 1, 1, 3, 3, 1, 5,
-	1, 1, 1,
+	2, 3, 3, 3, 5, 5, 3, 3, 3, 3,
+	3, 3, 3, 3, 3, 2, 1, 1, 1, 1,
+	3, 2, 3, 3, 1, 5, 4, 4, 4, 6,
+	6, 4, 4, 4, 6, 6, 3, 1, 1, 3,
+	3, 1, 1, 3, 5, 3, 1, 5, 1, 1,
+	1, 1,
 }
-var parserChk = [...]int{
 
-	-1000, -1, -2, -6, -4, -9, -7, 11, 8, 14,
-	-5, -3, -8, -10, -12, 4, 5, 6, 21, 23,
-	7, 25, 26, 27, 12, 9, 10, 17, 18, 15,
-	16, 13, 14, -4, 8, -4, -4, 14, -4, 5,
-	-2, -2, 21, 23, -6, -4, -4, -4, -4, -4,
-	-4, -4, -4, -4, -4, 14, -4, 27, 20, 22,
-	-11, -4, 20, -11, -4, -4, -4, 20, 19, 20,
-	19, 22, 22, 24, 19, -4, -4,
+var parserChk = [...]int{
+	-1000, -1, -2, -6, -4, -10, -7, 12, 15, 9,
+	-5, -3, -8, -9, -11, -13, 4, 5, 6, 7,
+	22, 24, 8, 26, 27, 28, 13, 30, 31, 32,
+	33, 35, 10, 11, 18, 19, 16, 17, 14, 15,
+	-4, 9, -4, -4, -4, 5, -2, 23, -4, -12,
+	22, 24, -6, -4, -4, -4, -4, -4, -4, -4,
+	-4, -4, -4, -4, -4, -4, -4, -4, 28, 21,
+	23, 20, 23, 20, -12, -14, -4, 21, -15, 8,
+	9, -12, -4, -14, 34, 34, -4, -4, -4, 21,
+	20, 21, 20, 20, 21, 29, 23, 20, 23, 20,
+	23, -4, -4, 25, 20, -14, -15, -4, -14, -4,
+	-14, -14, 21, 29, 21, 23, 23, -4,
 }
-var parserDef = [...]int{
 
-	0, -2, 1, 2, 4, 5, 8, 0, 35, 0,
-	22, 23, 24, 27, 0, 40, 41, 42, 0, 0,
-	34, 38, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 6, 35, 10, 7, 0, 21, 0,
-	0, 0, 0, 0, 3, 9, 11, 12, 13, 14,
-	15, 16, 17, 18, 20, 0, -2, 0, 25, 26,
-	0, 0, 33, 0, 0, 19, 0, 29, 0, 31,
-	0, 30, 32, 28, 39, 36, 37,
+var parserDef = [...]int{
+	0, -2, 1, 2, 4, 5, 8, 0, 0, 48,
+	26, 27, 28, 29, 34, 0, 58, 59, 60, 61,
+	0, 0, 47, 56, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	6, 48, 10, 25, 7, 0, 0, 31, 0, 0,
+	0, 0, 3, 9, 11, 12, 13, 0, 0, 16,
+	17, 18, 19, 20, 21, 22, 23, 24, 0, 30,
+	32, 0, 33, 0, 0, 0, 0, 46, 0, -2,
+	-2, 0, 0, 0, 0, 0, 0, 50, 49, 36,
+	0, 38, 0, 0, 41, 0, 37, 0, 42, 0,
+	43, 14, 15, 35, 57, 0, 0, 55, 0, 53,
+	0, 0, 40, 0, 39, 45, 44, 54,
 }
-var parserTok1 = [...]int{
 
+var parserTok1 = [...]int{
 	1,
 }
-var parserTok2 = [...]int{
 
+var parserTok2 = [...]int{
 	2, 3, 4, 5, 6, 7, 8, 9, 10, 11,
 	12, 13, 14, 15, 16, 17, 18, 19, 20, 21,
-	22, 23, 24, 25, 26, 27, 28, 29, 30,
+	22, 23, 24, 25, 26, 27, 28, 29, 30, 31,
+	32, 33, 34, 35, 36, 37, 38,
 }
+
 var parserTok3 = [...]int{
 	0,
 }
@@ -646,21 +704,21 @@ parserdefault:
 
 	case 1:
 		parserDollar = parserS[parserpt-1 : parserpt+1]
-//line parser.y:64
+//line parser.y:67
 		{
 			parserVAL.script = parserDollar[1].script
 			parserlex.(*parserLex).output = parserVAL.script
 		}
 	case 3:
 		parserDollar = parserS[parserpt-3 : parserpt+1]
-//line parser.y:79
+//line parser.y:82
 		{
 			parserDollar[1].script.Join(parserDollar[3].script)
 			parserVAL.script = parserDollar[1].script
 		}
 	case 5:
 		parserDollar = parserS[parserpt-1 : parserpt+1]
-//line parser.y:95
+//line parser.y:98
 		{
 			s, err := parserGetScript(parserDollar[1].sfunc)
 			if err != nil {
@@ -672,28 +730,28 @@ parserdefault:
 		}
 	case 6:
 		parserDollar = parserS[parserpt-2 : parserpt+1]
-//line parser.y:108
+//line parser.y:111
 		{
 			parserVAL.sfunc.transform = parserDollar[1].sfunc.transform
 			parserVAL.sfunc.args = append(parserDollar[1].sfunc.args, parserDollar[2].script)
 		}
 	case 7:
 		parserDollar = parserS[parserpt-2 : parserpt+1]
-//line parser.y:114
+//line parser.y:117
 		{
 			parserVAL.sfunc.transform = parserDollar[1].strVal
 			parserVAL.sfunc.args = []*Pipe{parserDollar[2].script}
 		}
 	case 9:
 		parserDollar = parserS[parserpt-3 : parserpt+1]
-//line parser.y:132
+//line parser.y:135
 		{
 			parserDollar[1].script.Join(parserDollar[3].script)
 			parserVAL.script = parserDollar[1].script
 		}
 	case 10:
 		parserDollar = parserS[parserpt-2 : parserpt+1]
-//line parser.y:138
+//line parser.y:141
 		{
 			s, err := notScript(parserDollar[2].script)
 			if err != nil {
@@ -704,7 +762,7 @@ parserdefault:
 		}
 	case 11:
 		parserDollar = parserS[parserpt-3 : parserpt+1]
-//line parser.y:149
+//line parser.y:152
 		{
 			s, err := comparisonScript(parserDollar[2].strVal, parserDollar[1].script, parserDollar[3].script)
 			if err != nil {
@@ -715,9 +773,9 @@ parserdefault:
 		}
 	case 12:
 		parserDollar = parserS[parserpt-3 : parserpt+1]
-//line parser.y:159
+//line parser.y:162
 		{
-			s, err := andScript(parserDollar[1].script, parserDollar[3].script)
+			s, err := NewElementPipe(InTransform, []*Pipe{parserDollar[1].script, parserDollar[3].script})
 			if err != nil {
 				parserlex.Error(err.Error())
 				goto ret1
@@ -726,9 +784,12 @@ parserdefault:
 		}
 	case 13:
 		parserDollar = parserS[parserpt-3 : parserpt+1]
-//line parser.y:169
+//line parser.y:173
 		{
-			s, err := orScript(parserDollar[1].script, parserDollar[3].script)
+			s, err := NewElementPipe(InTransform, []*Pipe{parserDollar[1].script, parserDollar[3].script})
+			if err == nil {
+				s, err = notScript(s)
+			}
 			if err != nil {
 				parserlex.Error(err.Error())
 				goto ret1
@@ -736,10 +797,10 @@ parserdefault:
 			parserVAL.script = s
 		}
 	case 14:
-		parserDollar = parserS[parserpt-3 : parserpt+1]
-//line parser.y:179
+		parserDollar = parserS[parserpt-5 : parserpt+1]
+//line parser.y:187
 		{
-			s, err := modScript(parserDollar[1].script, parserDollar[3].script)
+			s, err := NewElementPipe(BetweenTransform, []*Pipe{parserDollar[1].script, parserDollar[3].script, parserDollar[5].script})
 			if err != nil {
 				parserlex.Error(err.Error())
 				goto ret1
@@ -747,10 +808,13 @@ parserdefault:
 			parserVAL.script = s
 		}
 	case 15:
-		parserDollar = parserS[parserpt-3 : parserpt+1]
-//line parser.y:189
+		parserDollar = parserS[parserpt-5 : parserpt+1]
+//line parser.y:197
 		{
-			s, err := powScript(parserDollar[1].script, parserDollar[3].script)
+			s, err := NewElementPipe(BetweenTransform, []*Pipe{parserDollar[1].script, parserDollar[3].script, parserDollar[5].script})
+			if err == nil {
+				s, err = notScript(s)
+			}
 			if err != nil {
 				parserlex.Error(err.Error())
 				goto ret1
@@ -759,9 +823,9 @@ parserdefault:
 		}
 	case 16:
 		parserDollar = parserS[parserpt-3 : parserpt+1]
-//line parser.y:199
+//line parser.y:210
 		{
-			s, err := mulScript(parserDollar[1].script, parserDollar[3].script)
+			s, err := NewElementPipe(MatchTransform, []*Pipe{parserDollar[1].script, parserDollar[3].script})
 			if err != nil {
 				parserlex.Error(err.Error())
 				goto ret1
@@ -770,9 +834,9 @@ parserdefault:
 		}
 	case 17:
 		parserDollar = parserS[parserpt-3 : parserpt+1]
-//line parser.y:209
+//line parser.y:220
 		{
-			s, err := divScript(parserDollar[1].script, parserDollar[3].script)
+			s, err := andScript(parserDollar[1].script, parserDollar[3].script)
 			if err != nil {
 				parserlex.Error(err.Error())
 				goto ret1
@@ -781,9 +845,9 @@ parserdefault:
 		}
 	case 18:
 		parserDollar = parserS[parserpt-3 : parserpt+1]
-//line parser.y:219
+//line parser.y:230
 		{
-			s, err := addScript(parserDollar[1].script, parserDollar[3].script)
+			s, err := orScript(parserDollar[1].script, parserDollar[3].script)
 			if err != nil {
 				parserlex.Error(err.Error())
 				goto ret1
@@ -792,29 +856,62 @@ parserdefault:
 		}
 	case 19:
 		parserDollar = parserS[parserpt-3 : parserpt+1]
-//line parser.y:232
+//line parser.y:240
+		{
+			s, err := modScript(parserDollar[1].script, parserDollar[3].script)
+			if err != nil {
+				parserlex.Error(err.Error())
+				goto ret1
+			}
+			parserVAL.script = s
+		}
+	case 20:
+		parserDollar = parserS[parserpt-3 : parserpt+1]
+//line parser.y:250
 		{
-			// First get the script of this function
-			sf := scriptFunc{
-				transform: parserDollar[1].strVal,
-				args:      []*Pipe{},
+			s, err := powScript(parserDollar[1].script, parserDollar[3].script)
+			if err != nil {
+				parserlex.Error(err.Error())
+				goto ret1
 			}
-			s, err := parserGetScript(sf)
+			parserVAL.script = s
+		}
+	case 21:
+		parserDollar = parserS[parserpt-3 : parserpt+1]
+//line parser.y:260
+		{
+			s, err := mulScript(parserDollar[1].script, parserDollar[3].script)
 			if err != nil {
 				parserlex.Error(err.Error())
 				goto ret1
 			}
-			// Now subtract the two
-			s, err = subtractScript(s, parserDollar[3].script)
+			parserVAL.script = s
+		}
+	case 22:
+		parserDollar = parserS[parserpt-3 : parserpt+1]
+//line parser.y:270
+		{
+			s, err := divScript(parserDollar[1].script, parserDollar[3].script)
 			if err != nil {
 				parserlex.Error(err.Error())
 				goto ret1
 			}
 			parserVAL.script = s
 		}
-	case 20:
+	case 23:
+		parserDollar = parserS[parserpt-3 : parserpt+1]
+//line parser.y:280
+		{
+			s, err := addScript(parserDollar[1].script, parserDollar[3].script)
+			if err != nil {
+				parserlex.Error(err.Error())
+				goto ret1
+			}
+			parserVAL.script = s
+		}
+	case 24:
 		parserDollar = parserS[parserpt-3 : parserpt+1]
-//line parser.y:253
+//line parser.y:292
 		{
 			s, err := subtractScript(parserDollar[1].script, parserDollar[3].script)
 			if err != nil {
@@ -823,9 +920,9 @@ parserdefault:
 			}
 			parserVAL.script = s
 		}
-	case 21:
+	case 25:
 		parserDollar = parserS[parserpt-2 : parserpt+1]
-//line parser.y:263
+//line parser.y:302
 		{
 			s, err := negativeScript(parserDollar[2].script)
 			if err != nil {
@@ -834,21 +931,43 @@ parserdefault:
 			}
 			parserVAL.script = s
 		}
-	case 25:
+	case 30:
 		parserDollar = parserS[parserpt-3 : parserpt+1]
-//line parser.y:290
+//line parser.y:331
 		{
 			parserVAL.script = parserDollar[2].script
 		}
-	case 26:
+	case 31:
+		parserDollar = parserS[parserpt-2 : parserpt+1]
+//line parser.y:340
+		{
+			parserVAL.script = MustPipe(NewConstTransform([]interface{}{}), nil)
+		}
+	case 32:
+		parserDollar = parserS[parserpt-3 : parserpt+1]
+//line parser.y:345
+		{
+			s, err := NewElementPipe(ArrayTransform, []*Pipe{parserDollar[2].script})
+			if err != nil {
+				parserlex.Error(err.Error())
+				goto ret1
+			}
+			parserVAL.script = s
+		}
+	case 33:
 		parserDollar = parserS[parserpt-3 : parserpt+1]
-//line parser.y:292
+//line parser.y:355
 		{
-			parserVAL.script = parserDollar[2].script
+			s, err := NewElementPipe(ArrayTransform, parserDollar[2].scriptArray)
+			if err != nil {
+				parserlex.Error(err.Error())
+				goto ret1
+			}
+			parserVAL.script = s
 		}
-	case 27:
+	case 34:
 		parserDollar = parserS[parserpt-1 : parserpt+1]
-//line parser.y:301
+//line parser.y:371
 		{
 			s, err := parserGetScript(parserDollar[1].sfunc)
 			if err != nil {
@@ -859,9 +978,9 @@ parserdefault:
 			parserVAL.script = s
 
 		}
-	case 28:
+	case 35:
 		parserDollar = parserS[parserpt-5 : parserpt+1]
-//line parser.y:313
+//line parser.y:383
 		{
 			if _, ok := parserDollar[1].objBuilder[parserDollar[2].strVal]; ok {
 				parserlex.Error(fmt.Sprintf("Key %s found multiple times in json object", parserDollar[2].strVal))
@@ -872,77 +991,149 @@ parserdefault:
 			// Now generate the objectScript
 			parserVAL.script = MustPipe(NewObjectTransform(parserDollar[1].objBuilder), nil)
 		}
-	case 29:
+	case 36:
 		parserDollar = parserS[parserpt-4 : parserpt+1]
-//line parser.y:329
+//line parser.y:399
 		{
 			parserVAL.sfunc.transform = parserDollar[1].strVal
 			parserVAL.sfunc.args = parserDollar[3].scriptArray
 		}
-	case 30:
+	case 37:
 		parserDollar = parserS[parserpt-4 : parserpt+1]
-//line parser.y:336
+//line parser.y:406
 		{
 			parserVAL.sfunc.transform = parserDollar[1].strVal
 			parserVAL.sfunc.args = parserDollar[3].scriptArray
 		}
-	case 31:
+	case 38:
 		parserDollar = parserS[parserpt-4 : parserpt+1]
-//line parser.y:344
+//line parser.y:412
+		{
+			parserVAL.sfunc.transform = parserDollar[1].strVal
+			parserVAL.sfunc.args = []*Pipe{}
+			parserVAL.sfunc.kwargs = parserDollar[3].objBuilder
+		}
+	case 39:
+		parserDollar = parserS[parserpt-6 : parserpt+1]
+//line parser.y:419
 		{
 			parserVAL.sfunc.transform = parserDollar[1].strVal
 			parserVAL.sfunc.args = []*Pipe{parserDollar[3].script}
+			parserVAL.sfunc.kwargs = parserDollar[5].objBuilder
 		}
-	case 32:
+	case 40:
+		parserDollar = parserS[parserpt-6 : parserpt+1]
+//line parser.y:426
+		{
+			parserVAL.sfunc.transform = parserDollar[1].strVal
+			parserVAL.sfunc.args = parserDollar[3].scriptArray
+			parserVAL.sfunc.kwargs = parserDollar[5].objBuilder
+		}
+	case 41:
 		parserDollar = parserS[parserpt-4 : parserpt+1]
-//line parser.y:350
+//line parser.y:435
 		{
 			parserVAL.sfunc.transform = parserDollar[1].strVal
 			parserVAL.sfunc.args = []*Pipe{parserDollar[3].script}
 		}
-	case 33:
+	case 42:
+		parserDollar = parserS[parserpt-4 : parserpt+1]
+//line parser.y:441
+		{
+			parserVAL.sfunc.transform = parserDollar[1].strVal
+			parserVAL.sfunc.args = []*Pipe{parserDollar[3].script}
+		}
+	case 43:
+		parserDollar = parserS[parserpt-4 : parserpt+1]
+//line parser.y:448
+		{
+			parserVAL.sfunc.transform = parserDollar[1].strVal
+			parserVAL.sfunc.args = []*Pipe{}
+			parserVAL.sfunc.kwargs = parserDollar[3].objBuilder
+		}
+	case 44:
+		parserDollar = parserS[parserpt-6 : parserpt+1]
+//line parser.y:455
+		{
+			parserVAL.sfunc.transform = parserDollar[1].strVal
+			parserVAL.sfunc.args = []*Pipe{parserDollar[3].script}
+			parserVAL.sfunc.kwargs = parserDollar[5].objBuilder
+		}
+	case 45:
+		parserDollar = parserS[parserpt-6 : parserpt+1]
+//line parser.y:462
+		{
+			parserVAL.sfunc.transform = parserDollar[1].strVal
+			parserVAL.sfunc.args = parserDollar[3].scriptArray
+			parserVAL.sfunc.kwargs = parserDollar[5].objBuilder
+		}
+	case 46:
 		parserDollar = parserS[parserpt-3 : parserpt+1]
-//line parser.y:356
+//line parser.y:469
 		{
 			// Allows calling as a function
 			parserVAL.sfunc.transform = parserDollar[1].strVal
 			parserVAL.sfunc.args = []*Pipe{}
 		}
-	case 34:
+	case 47:
 		parserDollar = parserS[parserpt-1 : parserpt+1]
-//line parser.y:363
+//line parser.y:476
 		{
 			parserVAL.sfunc.transform = parserDollar[1].strVal
 			parserVAL.sfunc.args = []*Pipe{}
 		}
-	case 35:
+	case 48:
 		parserDollar = parserS[parserpt-1 : parserpt+1]
-//line parser.y:369
+//line parser.y:482
 		{
 			parserVAL.sfunc.transform = parserDollar[1].strVal
 			parserVAL.sfunc.args = []*Pipe{}
 		}
-	case 36:
+	case 49:
 		parserDollar = parserS[parserpt-3 : parserpt+1]
-//line parser.y:382
+//line parser.y:495
 		{
 			parserVAL.scriptArray = append(parserDollar[1].scriptArray, parserDollar[3].script)
 		}
-	case 37:
+	case 50:
 		parserDollar = parserS[parserpt-3 : parserpt+1]
-//line parser.y:387
+//line parser.y:500
 		{
 			parserVAL.scriptArray = []*Pipe{parserDollar[1].script, parserDollar[3].script}
 		}
-	case 38:
+	case 53:
+		parserDollar = parserS[parserpt-3 : parserpt+1]
+//line parser.y:515
+		{
+			parserVAL.objBuilder = map[string]*Pipe{parserDollar[1].strVal: parserDollar[3].script}
+		}
+	case 54:
+		parserDollar = parserS[parserpt-5 : parserpt+1]
+//line parser.y:520
+		{
+			if _, ok := parserDollar[1].objBuilder[parserDollar[3].strVal]; ok {
+				parserlex.Error(fmt.Sprintf("Arg %s given multiple times", parserDollar[3].strVal))
+				goto ret1
+			}
+			parserDollar[1].objBuilder[parserDollar[3].strVal] = parserDollar[5].script
+			parserVAL.objBuilder = parserDollar[1].objBuilder
+		}
+	case 55:
+		parserDollar = parserS[parserpt-3 : parserpt+1]
+//line parser.y:530
+		{
+			parserlex.Error("Positional args must come before args given by name")
+			goto ret1
+		}
+	case 56:
 		parserDollar = parserS[parserpt-1 : parserpt+1]
-//line parser.y:399
+//line parser.y:543
 		{
 			parserVAL.objBuilder = make(map[string]*Pipe)
 		}
-	case 39:
+	case 57:
 		parserDollar = parserS[parserpt-5 : parserpt+1]
-//line parser.y:404
+//line parser.y:548
 		{
 			if _, ok := parserDollar[1].objBuilder[parserDollar[2].strVal]; ok {
 				parserlex.Error(fmt.Sprintf("Key %s found multiple times in json object", parserDollar[2].strVal))
@@ -951,9 +1142,9 @@ parserdefault:
 			parserDollar[1].objBuilder[parserDollar[2].strVal] = parserDollar[4].script
 			parserVAL.objBuilder = parserDollar[1].objBuilder
 		}
-	case 40:
+	case 58:
 		parserDollar = parserS[parserpt-1 : parserpt+1]
-//line parser.y:422
+//line parser.y:566
 		{
 			num, err := strconv.ParseFloat(parserDollar[1].strVal, 64)
 			if err != nil {
@@ -962,15 +1153,15 @@ parserdefault:
 			}
 			parserVAL.script = MustPipe(NewConstTransform(num), nil)
 		}
-	case 41:
+	case 59:
 		parserDollar = parserS[parserpt-1 : parserpt+1]
-//line parser.y:432
+//line parser.y:576
 		{
 			parserVAL.script = MustPipe(NewConstTransform(parserDollar[1].strVal), nil)
 		}
-	case 42:
+	case 60:
 		parserDollar = parserS[parserpt-1 : parserpt+1]
-//line parser.y:437
+//line parser.y:581
 		{
 			if parserDollar[1].strVal == "true" {
 				parserVAL.script = MustPipe(NewConstTransform(true), nil)
@@ -978,6 +1169,12 @@ parserdefault:
 				parserVAL.script = MustPipe(NewConstTransform(false), nil)
 			}
 		}
+	case 61:
+		parserDollar = parserS[parserpt-1 : parserpt+1]
+//line parser.y:590
+		{
+			parserVAL.script = MustPipe(NewConstTransform(nil), nil)
+		}
 	}
 	goto parserstack /* stack new state and value */
 }
@@ -14,6 +14,7 @@ import (
 type scriptFunc struct {
 	transform string
 	args []*Pipe
+	kwargs map[string]*Pipe
 }
 
 
@@ -29,13 +30,15 @@ type scriptFunc struct {
 	strVal string	// This is how variables are passed in: by their string value
 }
 
-%type <script> script pipescript constant algebraic simpletransform transform statement parensvalue
+%type <script> script pipescript constant algebraic simpletransform transform statement parensvalue array_literal
 %type <sfunc> function simplefunction
 %type <scriptArray> script_array
-%type <objBuilder> object_builder
-%token <strVal> pNUMBER  pSTRING  pBOOL pIDENTIFIER pIDENTIFIER_SPACE
+%type <objBuilder> object_builder kwarg_list
+%type <strVal> kwarg_name
+%token <strVal> pNUMBER  pSTRING  pBOOL pNULL pIDENTIFIER pIDENTIFIER_SPACE
 %token <strVal> pAND pOR pNOT pCOMPARISON pPLUS pMINUS pMULTIPLY pDIVIDE pMODULO pPOW pCOMMA
-%token <strVal> pRPARENS pLPARENS pRSQUARE pLSQUARE pRBRACKET pLBRACKET pPIPE pCOLON
+%token <strVal> pRPARENS pLPARENS pRSQUARE pLSQUARE pRBRACKET pLBRACKET pPIPE pCOLON pASSIGN
+%token <strVal> pIN pNOT_IN pBETWEEN pNOT_BETWEEN pBETWEEN_AND pMATCH
 
 %nonassoc pNOARGS
 %nonassoc pLPARENS pLBRACKET pLSQUARE
@@ -46,7 +49,7 @@ type scriptFunc struct {
 %left pOR
 %left pAND
 %left pNOT
-%left pCOMPARISON
+%left pCOMPARISON pIN pNOT_IN pMATCH pBETWEEN pNOT_BETWEEN pBETWEEN_AND
 %left pPLUS pMINUS
 %left pMULTIPLY pDIVIDE
 %left pMODULO pPOW
@@ -155,6 +158,64 @@ algebraic: statement
 			$$ = s
 		}
 	|
+	algebraic pIN algebraic
+		{
+			s,err := NewElementPipe(InTransform,[]*Pipe{$1,$3})
+			if err!=nil {
+				parserlex.Error(err.Error())
+				goto ret1
+			}
+			$$ = s
+		}
+	|
+	/* The lexer gives "not in" as a single token, so that it isn't read as the start of a "not" */
+	algebraic pNOT_IN algebraic
+		{
+			s,err := NewElementPipe(InTransform,[]*Pipe{$1,$3})
+			if err==nil {
+				s,err = notScript(s)
+			}
+			if err!=nil {
+				parserlex.Error(err.Error())
+				goto ret1
+			}
+			$$ = s
+		}
+	|
+	/* The lexer gives the "and" of a between as pBETWEEN_AND, so that it isn't read as a logical and */
+	algebraic pBETWEEN algebraic pBETWEEN_AND algebraic
+		{
+			s,err := NewElementPipe(BetweenTransform,[]*Pipe{$1,$3,$5})
+			if err!=nil {
+				parserlex.Error(err.Error())
+				goto ret1
+			}
+			$$ = s
+		}
+	|
+	algebraic pNOT_BETWEEN algebraic pBETWEEN_AND algebraic
+		{
+			s,err := NewElementPipe(BetweenTransform,[]*Pipe{$1,$3,$5})
+			if err==nil {
+				s,err = notScript(s)
+			}
+			if err!=nil {
+				parserlex.Error(err.Error())
+				goto ret1
+			}
+			$$ = s
+		}
+	|
+	algebraic pMATCH algebraic
+		{
+			s,err := NewElementPipe(MatchTransform,[]*Pipe{$1,$3})
+			if err!=nil {
+				parserlex.Error(err.Error())
+				goto ret1
+			}
+			$$ = s
+		}
+	|
 	algebraic pAND algebraic
 		{
 			s,err := andScript($1,$3)
@@ -225,30 +286,8 @@ algebraic: statement
 			$$ = s
 		}
 	|
-	/* The parser has difficulty handling subtraction of IDENTIFIER_SPACE, since we have conflict with bash/function style transforms.
-	pIDENTIFIER_SPACE pMINUS algebraic -> pIDENTIFIER_SPACE (pMINUS algebraic) by default (unary minus)
-	We want it to work as normal subtraction. Resolve this here. */
-	pIDENTIFIER_SPACE pMINUS algebraic //%prec pSUPER
-	{
-		// First get the script of this function
-		sf := scriptFunc{
-			transform: $1,
-			args: []*Pipe{},
-		}
-		s,err := parserGetScript(sf)
-		if err!=nil {
-			parserlex.Error(err.Error())
-			goto ret1
-		}
-		// Now subtract the two
-		s,err = subtractScript(s,$3)
-		if err!=nil {
-			parserlex.Error(err.Error())
-			goto ret1
-		}
-		$$ = s
-	}
-	|
+	/* The lexer gives an identifier followed by a minus as pIDENTIFIER rather than pIDENTIFIER_SPACE,
+	so that x - 1 is a subtraction rather than x(-1) */
 	algebraic pMINUS algebraic %prec pMINUS
 		{
 			s,err := subtractScript($1,$3)
@@ -283,13 +322,44 @@ statement:
 	|
 	/* Set up the handlers of parentheses */
 	parensvalue
+	|
+	array_literal
 	;
 
 parensvalue:
 	/* Set up the handlers of parentheses */
 	pLPARENS pipescript pRPARENS { $$ = $2 }
+	;
+
+/*************************************************************************************
+array_literal builds an array from its elements: [a,b,c]
+*************************************************************************************/
+
+array_literal:
+	pLSQUARE pRSQUARE
+		{
+			$$ = MustPipe(NewConstTransform([]interface{}{}),nil)
+		}
 	|
-	pLSQUARE pipescript pRSQUARE { $$ = $2 }
+	pLSQUARE algebraic pRSQUARE
+		{
+			s,err := NewElementPipe(ArrayTransform,[]*Pipe{$2})
+			if err!=nil {
+				parserlex.Error(err.Error())
+				goto ret1
+			}
+			$$ = s
+		}
+	|
+	pLSQUARE script_array pRSQUARE
+		{
+			s,err := NewElementPipe(ArrayTransform,$2)
+			if err!=nil {
+				parserlex.Error(err.Error())
+				goto ret1
+			}
+			$$ = s
+		}
 	;
 
 /*************************************************************************************
@@ -338,6 +408,27 @@ simplefunction:
 			$$.args = $3
 		}
 	|
+	pIDENTIFIER pLPARENS kwarg_list pRPARENS
+		{
+			$$.transform = $1
+			$$.args = []*Pipe{}
+			$$.kwargs = $3
+		}
+	|
+	pIDENTIFIER pLPARENS algebraic pCOMMA kwarg_list pRPARENS
+		{
+			$$.transform = $1
+			$$.args = []*Pipe{$3}
+			$$.kwargs = $5
+		}
+	|
+	pIDENTIFIER pLPARENS script_array pCOMMA kwarg_list pRPARENS
+		{
+			$$.transform = $1
+			$$.args = $3
+			$$.kwargs = $5
+		}
+	|
 
 
 	pIDENTIFIER pLPARENS algebraic pRPARENS //%prec pARGS
@@ -352,6 +443,28 @@ simplefunction:
 			$$.args = []*Pipe{$3}
 		}
 	|
+	/* Args can be given by name inside brackets too: f[a,b=c] */
+	pIDENTIFIER pLSQUARE kwarg_list pRSQUARE
+		{
+			$$.transform = $1
+			$$.args = []*Pipe{}
+			$$.kwargs = $3
+		}
+	|
+	pIDENTIFIER pLSQUARE algebraic pCOMMA kwarg_list pRSQUARE
+		{
+			$$.transform = $1
+			$$.args = []*Pipe{$3}
+			$$.kwargs = $5
+		}
+	|
+	pIDENTIFIER pLSQUARE script_array pCOMMA kwarg_list pRSQUARE
+		{
+			$$.transform = $1
+			$$.args = $3
+			$$.kwargs = $5
+		}
+	|
 	pIDENTIFIER pLPARENS pRPARENS
 		{
 			// Allows calling as a function
@@ -390,6 +503,37 @@ script_array:
 	;
 
 
+/*************************************************************************************
+kwarg_list holds the args given by name in a function f(a,b,c=d,e=f). Named args
+must come after all positional args.
+*************************************************************************************/
+
+kwarg_name: pIDENTIFIER | pIDENTIFIER_SPACE ;
+
+kwarg_list:
+	kwarg_name pASSIGN algebraic
+		{
+			$$ = map[string]*Pipe{$1: $3}
+		}
+	|
+	kwarg_list pCOMMA kwarg_name pASSIGN algebraic
+		{
+			if _,ok := $1[$3]; ok {
+				parserlex.Error(fmt.Sprintf("Arg %s given multiple times",$3))
+				goto ret1
+			}
+			$1[$3] = $5
+			$$ = $1
+		}
+	|
+	kwarg_list pCOMMA algebraic
+		{
+			parserlex.Error("Positional args must come before args given by name")
+			goto ret1
+		}
+	;
+
+
 /*************************************************************************************
 object_builder allows us to read in a json-formatted object which includes transforms as values
 *************************************************************************************/
@@ -441,11 +585,26 @@ constant:
 				$$ = MustPipe(NewConstTransform(false),nil)
 			}
 		}
+	|
+	pNULL
+		{
+			$$ = MustPipe(NewConstTransform(nil),nil)
+		}
 	;
 
 %%
 
 func parserGetScript(sf scriptFunc) (*Pipe,error) {
+	if len(sf.transform) > 1 && sf.transform[0] == '$' {
+		// $name refers to a named input stream
+		if len(sf.args) > 0 || len(sf.kwargs) > 0 {
+			return nil, fmt.Errorf("The input stream '%s' does not take arguments", sf.transform)
+		}
+		return NewElementPipe(NewStreamTransform(sf.transform[1:]), nil)
+	}
+	if len(sf.kwargs) > 0 {
+		return NewKeywordTransformPipe(sf.transform,sf.args,sf.kwargs)
+	}
 	return NewTransformPipe(sf.transform,sf.args)
 }
 
@@ -20,10 +20,8 @@ func TestParserConst(t *testing.T) {
 		// Check operator prescedence
 		{"5+5/10", float64(5.5)},
 		{"(5+5)/10", float64(1)},
-		{"[5+5]/10", float64(1)},
 		{"5-5*10", float64(-45)},
 		{"(5-6)*-10", float64(10)},
-		{"[5-6]*-10", float64(10)},
 		{"true and 1 - 1", false},
 		{"false or 1 - 1", false},
 		{"(true and 1) - 1", float64(0)},
@@ -47,6 +45,74 @@ func TestParserConst(t *testing.T) {
 
 		// Test Pipe
 		{"5 | true", true},
+
+		// Test null propagation
+		{"null", nil},
+		{"null + 1", nil},
+		{"-null", nil},
+		{"2 * (null - 1)", nil},
+		{"null > 1", false},
+		{"null <= 1", false},
+		{"null == null", true},
+		{"null != 1", true},
+		{"not null", true},
+		{"null or true", true},
+
+		// Test number formats
+		{"1e-3", 0.001},
+		{"2.5E+2", float64(250)},
+		{".5", 0.5},
+		{"0x1F", float64(31)},
+		{"0XfF - 1", float64(254)},
+
+		// Test string escapes
+		{"'\\u00e9'", "é"},
+		{"\"\\ud83d\\ude00\"", "😀"},
+		{"'a\\/b\\b\\f'", "a/b\b\f"},
+		{"'\\d+'", "\\d+"},
+		{"'日本'", "日本"},
+
+		// Test duration and time literals
+		{"5m", float64(300)},
+		{"1h30m", float64(5400)},
+		{"2d", float64(172800)},
+		{"1w", float64(604800)},
+		{"1.5s + 250ms", 1.75},
+		{"@2024-01-01T00:00:00Z", float64(1704067200)},
+		{"@2024-01-01T01:00:00+01:00", float64(1704067200)},
+		{"@2024-03-01", float64(1709251200)},
+		{"@2024-03-01T00:00:30.5", 1709251230.5},
+		{"@2024-03-01 + 1d > @2024-03-01T12:00", true},
+
+		// Test array literals
+		{"[]", []interface{}{}},
+		{"[5+5]", []interface{}{float64(10)}},
+		{"[1, 'a', null, [true]]", []interface{}{float64(1), "a", nil, []interface{}{true}}},
+		{"[1, 2] == [1.0, 2]", true},
+		{"2 in [1, 2, 3]", true},
+		{"'b' not in ['a', 'c']", true},
+		{"array(1, 2)", []interface{}{float64(1), float64(2)}},
+
+		// Test extended comparisons
+		{"'apple' < 'banana'", true},
+		{"'b' >= 'a'", true},
+		{"'10' < '9'", true},
+		{"3 between 1 and 5", true},
+		{"6 between 1 and 5", false},
+		{"3 between 1+1 and 2*2", true},
+		{"3 between 1 and 5 and false", false},
+		{"'b' between 'a' and 'c'", true},
+		{"null between 1 and 5", false},
+		{"1 between (0 between 0 and 1) and 2", true},
+		{"6 not between 1 and 5", true},
+		{"3 not between 1 and 5 or true", true},
+		{"'b' in 'abc'", true},
+		{"null in 'abc'", false},
+		{"'x' in null", false},
+		{"'abc123' =~ '^[a-z]+\\d+$'", true},
+		{"'abc' =~ 'x'", false},
+		{"not 'abc' =~ 'x'", true},
+		{"5 =~ '5'", false},
 	}
 
 	for _, c := range cases {
@@ -58,6 +124,135 @@ func TestParserConst(t *testing.T) {
 	}
 }
 
+func TestArrayLiteral(t *testing.T) {
+	TestCase{
+		Pipescript: "[d('lat'), d('lon')]",
+		Input: []Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"lat": 1.5, "lon": 2.5}},
+			{Timestamp: 2, Data: map[string]interface{}{"lat": 3.5}},
+		},
+		Output: []Datapoint{
+			{Timestamp: 1, Data: []interface{}{1.5, 2.5}},
+			{Timestamp: 2, Data: []interface{}{3.5, nil}},
+		},
+	}.Run(t)
+
+	// Constant arrays are folded
+	TestCase{
+		Pipescript: "[1, [2, 3]] | d",
+		Parsed:     "[1,[2,3]]",
+		Input: []Datapoint{
+			{Timestamp: 1, Data: 4},
+		},
+		Output: []Datapoint{
+			{Timestamp: 1, Data: []interface{}{float64(1), []interface{}{float64(2), float64(3)}}},
+		},
+	}.Run(t)
+
+	// Peeking still uses brackets after an identifier
+	TestCase{
+		Pipescript: "[d, d[1]]",
+		Input: []Datapoint{
+			{Timestamp: 1, Data: 1},
+			{Timestamp: 2, Data: 2},
+		},
+		Output: []Datapoint{
+			{Timestamp: 1, Data: []interface{}{1, 2}},
+		},
+	}.Run(t)
+}
+
+func TestComparisons(t *testing.T) {
+	TestCase{
+		Pipescript: "d('x') in d('list') or d('obj') == {'a': {'b': 1}}",
+		Input: []Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"x": 2, "list": []interface{}{1.0, 2.0}, "obj": nil}},
+			{Timestamp: 2, Data: map[string]interface{}{"x": 3, "list": []interface{}{1.0, 2.0}, "obj": map[string]interface{}{"a": map[string]interface{}{"b": 1}}}},
+			{Timestamp: 3, Data: map[string]interface{}{"x": 3, "list": []interface{}{}, "obj": map[string]interface{}{"a": map[string]interface{}{"b": 2}}}},
+		},
+		Output: []Datapoint{
+			{Timestamp: 1, Data: true},
+			{Timestamp: 2, Data: true},
+			{Timestamp: 3, Data: false},
+		},
+	}.Run(t)
+	TestCase{
+		Pipescript: "'a' in d and 'b' not in d",
+		Input: []Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"a": 1}},
+			{Timestamp: 2, Data: map[string]interface{}{"a": 1, "b": 2}},
+			{Timestamp: 3, Data: map[string]interface{}{}},
+		},
+		Output: []Datapoint{
+			{Timestamp: 1, Data: true},
+			{Timestamp: 2, Data: false},
+			{Timestamp: 3, Data: false},
+		},
+	}.Run(t)
+}
+
+func TestBareComparisons(t *testing.T) {
+	// Comparisons of an identifier without brackets are not read as args of the identifier
+	for script, output := range map[string][]interface{}{
+		"d not in [1, 2]":       {false, true},
+		"d in [1, 2]":           {true, false},
+		"d between 1 and 5":     {true, false},
+		"d not between 1 and 5": {false, true},
+		"d - 1 in [1, 6]":       {true, true},
+		"d =~ '7'":              {false, false},
+	} {
+		TestCase{
+			Pipescript: script,
+			Input: []Datapoint{
+				{Timestamp: 1, Data: 2},
+				{Timestamp: 2, Data: 7},
+			},
+			Output: []Datapoint{
+				{Timestamp: 1, Data: output[0]},
+				{Timestamp: 2, Data: output[1]},
+			},
+		}.Run(t)
+	}
+	TestCase{
+		Pipescript: "d =~ 'x' or d not in ['b']",
+		Input: []Datapoint{
+			{Timestamp: 1, Data: "xy"},
+			{Timestamp: 2, Data: "ab"},
+			{Timestamp: 3, Data: "b"},
+		},
+		Output: []Datapoint{
+			{Timestamp: 1, Data: true},
+			{Timestamp: 2, Data: true},
+			{Timestamp: 3, Data: false},
+		},
+	}.Run(t)
+}
+
+func TestComparisonErrors(t *testing.T) {
+	for _, script := range []string{
+		"1 between 2",
+		"1 between 2 or 3",
+		"(1 between 2) and 3",
+		"'a' in 5",
+		"'a' =~ '('",
+		"1 not between 2",
+		"1 not 2",
+	} {
+		p, err := Parse(script)
+		if err == nil {
+			_, err = p.GetConst()
+		}
+		require.Error(t, err, script)
+	}
+}
+
+func TestParserLiteralErrors(t *testing.T) {
+	for _, script := range []string{"5min", "1h30", "@2024-13-01", "@2024-1-1", "3d2", "0x", "'abc", "'\\u12'", "1 ! 2", "#"} {
+		_, err := Parse(script)
+		require.Error(t, err, script)
+	}
+}
+
 func TestParser(t *testing.T) {
 	// Here we perform more advanced pipes to make sure everything works as it should in the parser
 	// We assume all built-in functions are available
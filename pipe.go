@@ -4,14 +4,30 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"sort"
+	"strings"
 )
 
 type TransformEnv struct {
 	Iter     *BufferIterator
 	ArgIters []*BufferIterator
+
+	// Streams holds the named input streams of the pipe, if they were given
+	Streams map[string]*Buffer
+}
+
+// argSlice returns a slice with room for the value of each transform arg, reusing args if it is large enough.
+// The number of args is only known once the transform is used, since variadic args can be given any number of times.
+func (te *TransformEnv) argSlice(args []*Datapoint) []*Datapoint {
+	if cap(args) < len(te.ArgIters) {
+		return make([]*Datapoint, len(te.ArgIters))
+	}
+	return args[:len(te.ArgIters)]
 }
 
 func (te *TransformEnv) Next(args []*Datapoint) (*Datapoint, []*Datapoint, error) {
+	args = te.argSlice(args)
 	dp, err := te.Iter.Next()
 	if err == nil && dp != nil {
 		for i := range te.ArgIters {
@@ -28,6 +44,7 @@ func (te *TransformEnv) Next(args []*Datapoint) (*Datapoint, []*Datapoint, error
 }
 
 func (te *TransformEnv) Peek(idx int, args []*Datapoint) (*Datapoint, []*Datapoint, error) {
+	args = te.argSlice(args)
 	dp, err := te.Iter.Peek(idx)
 	if err == nil && dp != nil {
 		for i := range te.ArgIters {
@@ -60,27 +77,37 @@ func NewPipeElement(t *Transform, args []*Pipe) (*PipeElement, error) {
 	consts := make([]interface{}, 0)
 	pipes := make([]*Pipe, 0)
 
-	if len(args) > len(t.Args) {
+	if len(args) > len(t.Args) && !t.IsVariadic() {
 		return nil, fmt.Errorf("Transform '%s' takes %d arguments, but %d given", t.Name, len(t.Args), len(args))
 	}
 
 	for i := range t.Args {
 		if len(args) <= i {
 			if t.Args[i].Optional {
-				args = append(args, t.Args[i].Default)
+				// An optional variadic arg can be left out entirely
+				if !t.Args[i].Variadic {
+					args = append(args, t.Args[i].defaultPipe())
+				}
 			} else {
 				return nil, fmt.Errorf("Transform '%s' requires additional arguments", t.Name)
 			}
 		}
+	}
+
+	for i := range args {
 		args[i].Simplify()
 
 		// Now split the args up
-		switch t.Args[i].Type {
+		switch t.Arg(i).Type {
 		case ConstArgType:
 			cv, err := args[i].GetConst()
 			if err != nil {
 				return nil, err
 			}
+			cv, err = t.Arg(i).Validate(cv)
+			if err != nil {
+				return nil, fmt.Errorf("Transform '%s' arg %d (%s): %w", t.Name, i, t.Arg(i).label(), err)
+			}
 			consts = append(consts, cv)
 		case TransformArgType:
 			targs = append(targs, args[i])
@@ -115,18 +142,87 @@ func NewPipeElement(t *Transform, args []*Pipe) (*PipeElement, error) {
 	return pe, err
 }
 
+// operatorSymbols holds the symbols of the transforms that the parser creates from operators, so that they can be
+// written back as pipescript
+var operatorSymbols = map[*Transform]string{
+	AddTransform:   "+",
+	SubTransform:   "-",
+	MulTransform:   "*",
+	DivTransform:   "/",
+	ModTransform:   "%",
+	PowTransform:   "^",
+	EqTransform:    "==",
+	NeTransform:    "!=",
+	LtTransform:    "<",
+	LteTransform:   "<=",
+	GtTransform:    ">",
+	GteTransform:   ">=",
+	AndTransform:   "and",
+	OrTransform:    "or",
+	InTransform:    "in",
+	MatchTransform: "=~",
+}
+
+// constString writes a constant value as pipescript. Values that JSON can't represent, like infinity,
+// are written as the arithmetic that gives them.
+func constString(v interface{}) string {
+	switch c := v.(type) {
+	case float64:
+		switch {
+		case math.IsNaN(c):
+			return "(0/0)"
+		case math.IsInf(c, 1):
+			return "(1/0)"
+		case math.IsInf(c, -1):
+			return "(-1/0)"
+		}
+	case []interface{}:
+		s := make([]string, len(c))
+		for i := range c {
+			s[i] = constString(c[i])
+		}
+		return "[" + strings.Join(s, ",") + "]"
+	case map[string]interface{}:
+		keys := make([]string, 0, len(c))
+		for k := range c {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		s := make([]string, len(keys))
+		for i, k := range keys {
+			s[i] = constString(k) + ":" + constString(c[k])
+		}
+		return "{" + strings.Join(s, ",") + "}"
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
 func (pe *PipeElement) String() string {
+	if op, ok := operatorSymbols[pe.Transform]; ok && len(pe.Args) == 2 {
+		return "(" + pe.Args[0].String() + " " + op + " " + pe.Args[1].String() + ")"
+	}
+	switch pe.Transform {
+	case BetweenTransform:
+		return "(" + pe.Args[0].String() + " between " + pe.Args[1].String() + " and " + pe.Args[2].String() + ")"
+	case ArrayTransform:
+		s := make([]string, len(pe.Args))
+		for i := range pe.Args {
+			s[i] = pe.Args[i].String()
+		}
+		return "[" + strings.Join(s, ",") + "]"
+	}
 	s := pe.Transform.Name
-	if len(pe.Transform.Args) > 0 {
+	nargs := len(pe.ConstArgs) + len(pe.Args) + len(pe.PipeArgs)
+	if nargs > 0 {
 		s += "("
 		tai := 0
 		pai := 0
 		cai := 0
-		for i := range pe.Transform.Args {
-			switch pe.Transform.Args[i].Type {
+		for i := 0; i < nargs; i++ {
+			switch pe.Transform.Arg(i).Type {
 			case ConstArgType:
-				b, _ := json.Marshal(pe.ConstArgs[cai])
-				s += string(b)
+				s += constString(pe.ConstArgs[cai])
 				cai++
 			case TransformArgType:
 				s += pe.Args[tai].String()
@@ -197,6 +293,9 @@ func (pe *PipeElement) Copy() *PipeElement {
 	if err != nil {
 		panic(err)
 	}
+	if pe.Env.Streams != nil {
+		pnew.inputStreams(pe.Env.Streams)
+	}
 	return pnew
 }
 
@@ -250,6 +349,21 @@ func NewTransformPipe(tname string, args []*Pipe) (*Pipe, error) {
 	return NewElementPipe(t, args)
 }
 
+// NewKeywordTransformPipe is like NewTransformPipe, but also accepts args given by name
+func NewKeywordTransformPipe(tname string, args []*Pipe, kwargs map[string]*Pipe) (*Pipe, error) {
+	RegistryLock.RLock()
+	defer RegistryLock.RUnlock()
+	t, ok := TransformRegistry[tname]
+	if !ok {
+		return nil, fmt.Errorf("Could not find transform '%s'", tname)
+	}
+	args, err := t.KeywordArgs(args, kwargs)
+	if err != nil {
+		return nil, err
+	}
+	return NewElementPipe(t, args)
+}
+
 // Input sets the pipe's input to the given buffer
 func (p Pipe) Input(b *Buffer) {
 	if len(p.Arr) > 0 {
@@ -320,13 +434,28 @@ func (p *Pipe) OneToOne() bool {
 	return true
 }
 
+// String returns the pipe written as pipescript, which can be parsed back into the same pipe
 func (p *Pipe) String() string {
-	if len(p.Arr) == 0 {
-		return ""
-	}
-	s := p.Arr[0].String()
-	for i := 1; i < len(p.Arr); i++ {
-		s += ":" + p.Arr[i].String()
+	s := ""
+	for i := range p.Arr {
+		switch p.Arr[i].Transform {
+		case NotTransform, NegTransform:
+			// not and neg are applied to the output of the elements before them, or to the data if there are none
+			in := "d"
+			if s != "" {
+				in = "(" + s + ")"
+			}
+			if p.Arr[i].Transform == NotTransform {
+				s = "(not " + in + ")"
+			} else {
+				s = "(-" + in + ")"
+			}
+		default:
+			if s != "" {
+				s += ":"
+			}
+			s += p.Arr[i].String()
+		}
 	}
 	return s
 }
@@ -529,7 +658,9 @@ func NewChannelPipe(p *Pipe) *ChannelPipe {
 func Parse(script string) (*Pipe, error) {
 	lexer := parserLex{input: script}
 
-	if parserParse(&lexer) != 0 {
+	// The lexer stops at the first invalid token, so a lexer error means that the script was not fully parsed,
+	// even if the parser succeeded
+	if parserParse(&lexer) != 0 || lexer.errorString != "" {
 		if lexer.errorString != "" {
 			return nil, fmt.Errorf("'%s': %s", script, lexer.errorString)
 		}
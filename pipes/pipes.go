@@ -7,12 +7,14 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime/pprof"
 
 	"github.com/heedy/pipescript"
@@ -36,12 +38,53 @@ func getWriter(s string) (*os.File, error) {
 	return os.Create(s)
 }
 
-func runner(c *cli.Context, str string) {
+// getIterator reads the datapoints of the given input file, in the format given by the command's flags
+func getIterator(c *cli.Context, fname string) (pipescript.Iterator, error) {
+	r, err := getReader(fname)
+	if err != nil {
+		return nil, err
+	}
 
-	r, err := getReader(c.String("input"))
+	var dpr pipescript.Iterator
+	switch c.String("ifmt") {
+	case "dpa":
+		dpr, err = bytestreams.NewArrayReader(r)
+	case "dp":
+		dpr, err = bytestreams.NewDatapointReader(r)
+	case "json":
+		dpr, err = bytestreams.NewJSONDatapointReader(r, c.String("timestamp"), c.Bool("notimestamp"))
+	case "csv":
+		dpr, err = bytestreams.NewCSVDatapointReader(r, c.String("timestamp"), c.Bool("notimestamp"))
+	default:
+		return nil, errors.New("Unrecognized input format")
+	}
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
+	}
+
+	if c.IsSet("reorder") || c.IsSet("reordercount") {
+		dpr = pipescript.NewReorderIterator(dpr, c.Float64("reorder"), c.Int("reordercount"))
+	}
+	return dpr, nil
+}
+
+// streamInput matches inputs of the form name=file, which give a named input stream
+var streamInput = regexp.MustCompile(`^([\p{L}_][\p{L}\d_]*)=(.*)$`)
+
+// namedInput returns the name and file of a named input stream. An existing file whose name looks like
+// name=file is read as a file, and other files can be given with a path, such as ./a=b.json
+func namedInput(in string) (name string, file string, ok bool) {
+	m := streamInput.FindStringSubmatch(in)
+	if m == nil {
+		return "", "", false
 	}
+	if _, err := os.Stat(in); err == nil {
+		return "", "", false
+	}
+	return m[1], m[2], true
+}
+
+func runner(c *cli.Context, str string) {
 	w, err := getWriter(c.String("output"))
 	if err != nil {
 		log.Fatal(err)
@@ -52,34 +95,39 @@ func runner(c *cli.Context, str string) {
 		log.Fatal(fmt.Errorf("%s", err.Error()))
 	}
 
-	// Now set up the datapoint reader
-	var dpr pipescript.Iterator
-	switch c.String("ifmt") {
-	case "dpa":
-		dpr, err = bytestreams.NewArrayReader(r)
-		if err != nil {
-			log.Fatal(err)
+	// Now set up the datapoint readers. The input without a name is the main input of the script,
+	// and the others are named streams
+	input := "STDIN"
+	hasInput := false
+	streams := make(map[string]pipescript.Iterator)
+	for _, in := range c.StringSlice("input") {
+		if name, file, ok := namedInput(in); ok {
+			if _, ok := streams[name]; ok {
+				log.Fatalf("The input stream '%s' was given more than once", name)
+			}
+			streams[name], err = getIterator(c, file)
+			if err != nil {
+				log.Fatal(err)
+			}
+			continue
 		}
-	case "dp":
-
-		dpr, err = bytestreams.NewDatapointReader(r)
-		if err != nil {
-			log.Fatal(err)
+		if hasInput {
+			log.Fatal("Only one input can be given without a name")
 		}
-	case "json":
-		dpr, err = bytestreams.NewJSONDatapointReader(r, c.String("timestamp"), c.Bool("notimestamp"))
-		if err != nil {
-			log.Fatal(err)
-		}
-	case "csv":
-		dpr, err = bytestreams.NewCSVDatapointReader(r, c.String("timestamp"), c.Bool("notimestamp"))
-		if err != nil {
-			log.Fatal(err)
+		input = in
+		hasInput = true
+	}
+	for _, name := range s.Streams() {
+		if _, ok := streams[name]; !ok {
+			log.Fatalf("The script uses the input stream '$%s', which can be given with -i %s=FILE", name, name)
 		}
-	default:
-		log.Fatal("Unrecognized input format")
 	}
 
+	dpr, err := getIterator(c, input)
+	if err != nil {
+		log.Fatal(err)
+	}
+	s.InputStreams(streams)
 	s.InputIterator(dpr)
 
 	// Now set the output json stream writer
@@ -198,10 +246,9 @@ func main() {
 				return nil
 			},
 			Flags: []cli.Flag{
-				cli.StringFlag{
+				cli.StringSliceFlag{
 					Name:  "input,i",
-					Value: "STDIN",
-					Usage: "The input file to perform analysis on.",
+					Usage: "The input file to perform analysis on (STDIN by default). Additional named input streams are given as name=file, and used in the script as $name. Files with an '=' in their name can be given as ./name=file.",
 				},
 				cli.StringFlag{
 					Name:  "output,o",
@@ -226,6 +273,14 @@ func main() {
 					Value: "",
 					Usage: "Allows to explicitly set the field name to use for timestamp values",
 				},
+				cli.Float64Flag{
+					Name:  "reorder",
+					Usage: "Sorts input datapoints that are out of order by up to the given number of seconds. 0 sorts the entire input.",
+				},
+				cli.IntFlag{
+					Name:  "reordercount",
+					Usage: "The maximum number of input datapoints to hold while sorting with --reorder",
+				},
 				cli.StringFlag{
 					Name:  "cpuprofile",
 					Value: "",
@@ -0,0 +1,92 @@
+package pipescript
+
+import (
+	"container/heap"
+	"fmt"
+)
+
+type reorderItem struct {
+	dp  Datapoint
+	seq int // The order in which the datapoint arrived, so that datapoints with equal timestamps keep their order
+}
+
+type reorderHeap []reorderItem
+
+func (h reorderHeap) Len() int { return len(h) }
+func (h reorderHeap) Less(i, j int) bool {
+	if h[i].dp.Timestamp == h[j].dp.Timestamp {
+		return h[i].seq < h[j].seq
+	}
+	return h[i].dp.Timestamp < h[j].dp.Timestamp
+}
+func (h reorderHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *reorderHeap) Push(x interface{}) { *h = append(*h, x.(reorderItem)) }
+func (h *reorderHeap) Pop() interface{} {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
+
+// ReorderIterator sorts a stream whose datapoints might arrive slightly out of order, such as data uploaded from phones.
+// Datapoints are held until a datapoint MaxLag seconds newer has arrived, or until more than MaxCount datapoints
+// are waiting. A datapoint that arrives after a newer one was already returned gives an error, since it is outside
+// the bounds. If both bounds are 0, the entire stream is read and sorted.
+type ReorderIterator struct {
+	MaxLag   float64
+	MaxCount int
+
+	it      Iterator
+	in      Datapoint
+	buf     reorderHeap
+	seq     int
+	newest  float64
+	last    float64
+	started bool
+	done    bool
+}
+
+// NewReorderIterator sorts the datapoints of the given iterator by timestamp, within the given bounds
+func NewReorderIterator(it Iterator, maxlag float64, maxcount int) *ReorderIterator {
+	return &ReorderIterator{
+		MaxLag:   maxlag,
+		MaxCount: maxcount,
+		it:       it,
+	}
+}
+
+// ready returns true if the oldest buffered datapoint can be returned
+func (r *ReorderIterator) ready() bool {
+	if len(r.buf) == 0 {
+		return false
+	}
+	return (r.MaxCount > 0 && len(r.buf) > r.MaxCount) || (r.MaxLag > 0 && r.newest-r.buf[0].dp.Timestamp >= r.MaxLag)
+}
+
+func (r *ReorderIterator) Next(out *Datapoint) (*Datapoint, error) {
+	for !r.done && !r.ready() {
+		dp, err := r.it.Next(&r.in)
+		if err != nil {
+			return nil, err
+		}
+		if dp == nil {
+			r.done = true
+			break
+		}
+		if r.started && dp.Timestamp < r.last {
+			return nil, fmt.Errorf("Datapoint with timestamp %v arrived after the datapoint with timestamp %v, which is too late to reorder", dp.Timestamp, r.last)
+		}
+		if r.seq == 0 || dp.Timestamp > r.newest {
+			r.newest = dp.Timestamp
+		}
+		heap.Push(&r.buf, reorderItem{dp: *dp, seq: r.seq})
+		r.seq++
+	}
+	if len(r.buf) == 0 {
+		return nil, nil
+	}
+	*out = heap.Pop(&r.buf).(reorderItem).dp
+	r.last = out.Timestamp
+	r.started = true
+	return out, nil
+}
@@ -0,0 +1,62 @@
+package pipescript
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func readTimestamps(t *testing.T, it Iterator) ([]float64, error) {
+	var res []float64
+	for {
+		dp, err := it.Next(&Datapoint{})
+		if err != nil || dp == nil {
+			return res, err
+		}
+		res = append(res, dp.Timestamp)
+	}
+}
+
+func TestReorderIterator(t *testing.T) {
+	input := func(timestamps ...float64) Iterator {
+		dpa := make([]Datapoint, len(timestamps))
+		for i, ts := range timestamps {
+			dpa[i] = Datapoint{Timestamp: ts, Data: i}
+		}
+		return NewDatapointArrayIterator(dpa)
+	}
+
+	res, err := readTimestamps(t, NewReorderIterator(input(1, 3, 2, 5, 4, 10, 9), 2, 0))
+	require.NoError(t, err)
+	require.Equal(t, []float64{1, 2, 3, 4, 5, 9, 10}, res)
+
+	// With no bounds, the whole stream is sorted
+	res, err = readTimestamps(t, NewReorderIterator(input(5, 4, 3, 2, 1), 0, 0))
+	require.NoError(t, err)
+	require.Equal(t, []float64{1, 2, 3, 4, 5}, res)
+
+	res, err = readTimestamps(t, NewReorderIterator(input(2, 1, 4, 3), 0, 1))
+	require.NoError(t, err)
+	require.Equal(t, []float64{1, 2, 3, 4}, res)
+
+	// A datapoint that is too late gives an error
+	res, err = readTimestamps(t, NewReorderIterator(input(1, 5, 8, 2), 2, 0))
+	require.Error(t, err)
+	require.Equal(t, []float64{1, 5}, res)
+
+	res, err = readTimestamps(t, NewReorderIterator(input(3, 2, 1), 0, 1))
+	require.Error(t, err)
+	require.Equal(t, []float64{2}, res)
+
+	// Equal timestamps keep their order
+	it := NewReorderIterator(NewDatapointArrayIterator([]Datapoint{
+		{Timestamp: 2, Data: "a"},
+		{Timestamp: 1, Data: "b"},
+		{Timestamp: 2, Data: "c"},
+	}), 0, 0)
+	for _, v := range []string{"b", "a", "c"} {
+		dp, err := it.Next(&Datapoint{})
+		require.NoError(t, err)
+		require.Equal(t, v, dp.Data)
+	}
+}
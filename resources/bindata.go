@@ -1,27 +1,72 @@
 // Code generated by go-bindata.
 // sources:
+// resources/docs/transforms/afilter.md
 // resources/docs/transforms/alltrue.md
+// resources/docs/transforms/amap.md
 // resources/docs/transforms/anytrue.md
+// resources/docs/transforms/asof.md
+// resources/docs/transforms/bbox.md
+// resources/docs/transforms/bearing.md
 // resources/docs/transforms/bucket.md
+// resources/docs/transforms/centroid.md
 // resources/docs/transforms/changed.md
+// resources/docs/transforms/coalesce.md
 // resources/docs/transforms/contains.md
+// resources/docs/transforms/corr.md
 // resources/docs/transforms/count.md
+// resources/docs/transforms/cov.md
+// resources/docs/transforms/cusum.md
 // resources/docs/transforms/d.md
+// resources/docs/transforms/dedup.md
+// resources/docs/transforms/default.md
 // resources/docs/transforms/distance.md
 // resources/docs/transforms/dt.md
 // resources/docs/transforms/first.md
+// resources/docs/transforms/geohash.md
+// resources/docs/transforms/groupby.md
 // resources/docs/transforms/i.md
+// resources/docs/transforms/incircle.md
+// resources/docs/transforms/ingeofence.md
+// resources/docs/transforms/int.md
+// resources/docs/transforms/iqr_outlier.md
+// resources/docs/transforms/isnull.md
+// resources/docs/transforms/json_parse.md
 // resources/docs/transforms/last.md
+// resources/docs/transforms/levenshtein.md
+// resources/docs/transforms/linreg.md
+// resources/docs/transforms/mad_zscore.md
 // resources/docs/transforms/map.md
 // resources/docs/transforms/mean.md
+// resources/docs/transforms/ngrams.md
+// resources/docs/transforms/partition.md
+// resources/docs/transforms/pathlength.md
+// resources/docs/transforms/pattern.md
+// resources/docs/transforms/pick.md
 // resources/docs/transforms/reduce.md
 // resources/docs/transforms/regex.md
+// resources/docs/transforms/regex_extract.md
+// resources/docs/transforms/regex_findall.md
+// resources/docs/transforms/regex_parse.md
+// resources/docs/transforms/regex_replace.md
+// resources/docs/transforms/reorder.md
+// resources/docs/transforms/round.md
+// resources/docs/transforms/set.md
+// resources/docs/transforms/settime.md
+// resources/docs/transforms/similarity.md
+// resources/docs/transforms/speed.md
+// resources/docs/transforms/staypoints.md
+// resources/docs/transforms/strftime.md
 // resources/docs/transforms/sum.md
 // resources/docs/transforms/t.md
+// resources/docs/transforms/tokens.md
+// resources/docs/transforms/trend.md
+// resources/docs/transforms/trips.md
 // resources/docs/transforms/tshift.md
 // resources/docs/transforms/wc.md
 // resources/docs/transforms/where.md
 // resources/docs/transforms/while.md
+// resources/docs/transforms/wordfreq.md
+// resources/docs/transforms/zscore.md
 // DO NOT EDIT!
 
 package resources
@@ -65,6 +110,38 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
+var _docsTransformsAfilterMd = []byte(`The `+"`"+`afilter`+"`"+` transform returns an array containing only the elements for which its argument is true. Inside the argument, `+"`"+`d`+"`"+` refers to the current array element.
+
+Given the following data:
+
+`+"`"+``+"`"+``+"`"+`json
+[[1, 5, 2, 8], [3]]
+`+"`"+``+"`"+``+"`"+`
+
+`+"`"+`afilter(d > 2)`+"`"+` will return:
+
+`+"`"+``+"`"+``+"`"+`json
+[[5, 8], [3]]
+`+"`"+``+"`"+``+"`"+`
+
+Elements for which the statement is `+"`"+`null`+"`"+` are removed. The `+"`"+`length`+"`"+` transform can then count the matching elements: `+"`"+`afilter(d("signal") > -70) | length`+"`"+` returns the number of wifi networks with a strong signal.
+`)
+
+func docsTransformsAfilterMdBytes() ([]byte, error) {
+	return _docsTransformsAfilterMd, nil
+}
+
+func docsTransformsAfilterMd() (*asset, error) {
+	bytes, err := docsTransformsAfilterMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/afilter.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _docsTransformsAlltrueMd = []byte(`Given the following data:
 
 `+"`"+``+"`"+``+"`"+`
@@ -104,6 +181,45 @@ func docsTransformsAlltrueMd() (*asset, error) {
 	return a, nil
 }
 
+var _docsTransformsAmapMd = []byte(`The `+"`"+`amap`+"`"+` transform runs the transform given in its argument over each element of an array, and returns an array of the results. It is like `+"`"+`reduce`+"`"+`, except that it returns all of the outputs instead of only the last one.
+
+Inside the argument, `+"`"+`d`+"`"+` refers to the current array element. Given a list of nearby wifi networks:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  [
+    { "ssid": "home", "signal": -40 },
+    { "ssid": "cafe", "signal": -80 }
+  ]
+]
+`+"`"+``+"`"+``+"`"+`
+
+`+"`"+`amap(d("ssid"))`+"`"+` will return:
+
+`+"`"+``+"`"+``+"`"+`json
+[["home", "cafe"]]
+`+"`"+``+"`"+``+"`"+`
+
+The argument can be any transform, including ones that do not return one datapoint per element. For example, `+"`"+`amap(where(d > 2))`+"`"+` keeps only the elements greater than 2, and `+"`"+`amap(sum)`+"`"+` returns a single-element array with the sum.
+
+Combine with other array transforms to build more complex queries. The strongest network in each scan is found with `+"`"+`asort(d("signal"), true) | aindex(0) | d("ssid")`+"`"+`.
+`)
+
+func docsTransformsAmapMdBytes() ([]byte, error) {
+	return _docsTransformsAmapMd, nil
+}
+
+func docsTransformsAmapMd() (*asset, error) {
+	bytes, err := docsTransformsAmapMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/amap.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _docsTransformsAnytrueMd = []byte(`The `+"`"+`anytrue`+"`"+` transform returns `+"`"+`true`+"`"+` if any of the datapoints in the stream were true.
 
 Given the following data:
@@ -145,6 +261,149 @@ func docsTransformsAnytrueMd() (*asset, error) {
 	return a, nil
 }
 
+var _docsTransformsAsofMd = []byte(`The `+"`"+`asof`+"`"+` transform combines a second stream with the current one. It returns the value of a named input stream at the timestamp of each datapoint, computed by one of the registered interpolators.
+
+Named input streams are given by the application running the query, or with extra inputs to `+"`"+`pipes run`+"`"+`:
+
+`+"`"+``+"`"+``+"`"+`
+pipes run -i steps.json -i heartrate=heartrate.json "asof(\$heartrate)"
+`+"`"+``+"`"+``+"`"+`
+
+Given the following steps as input:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 1, "d": 10 },
+  { "t": 5, "d": 12 }
+]
+`+"`"+``+"`"+``+"`"+`
+
+and the following `+"`"+`heartrate`+"`"+` stream:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 0, "d": 70 },
+  { "t": 4, "d": 85 },
+  { "t": 9, "d": 90 }
+]
+`+"`"+``+"`"+``+"`"+`
+
+`+"`"+`asof($heartrate)`+"`"+` returns the heart rate with the closest timestamp to each step datapoint:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 1, "d": 70 },
+  { "t": 5, "d": 85 }
+]
+`+"`"+``+"`"+``+"`"+`
+
+The second argument chooses the interpolator, which is `+"`"+`closest`+"`"+` by default. If it is not the name of an interpolator, it is run as a transform on the datapoints of the stream since the previous datapoint. For example, `+"`"+`asof($heartrate, "count")`+"`"+` returns the number of heart rate datapoints since the previous step datapoint.
+
+Transforms can follow the stream, so `+"`"+`asof($phone:d("battery"))`+"`"+` gets the `+"`"+`battery`+"`"+` field of the `+"`"+`phone`+"`"+` stream. The result can be used in any expression, such as `+"`"+`{"steps": d, "hr": asof($heartrate)}`+"`"+`. Both streams must be ordered by timestamp.
+`)
+
+func docsTransformsAsofMdBytes() ([]byte, error) {
+	return _docsTransformsAsofMd, nil
+}
+
+func docsTransformsAsofMd() (*asset, error) {
+	bytes, err := docsTransformsAsofMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/asof.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _docsTransformsBboxMd = []byte(`The `+"`"+`bbox`+"`"+` transform returns the bounding box of the locations of the datapoints, which is the smallest range of latitudes and longitudes that contains all of them. It expects datapoints with `+"`"+`latitude`+"`"+` and `+"`"+`longitude`+"`"+` fields in decimal coordinates.
+
+Given the following locations:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 0, "d": { "latitude": 40.4243, "longitude": -86.9114 } },
+  { "t": 60, "d": { "latitude": 40.4263, "longitude": -86.9114 } },
+  { "t": 120, "d": { "latitude": 40.4263, "longitude": -86.9084 } }
+]
+`+"`"+``+"`"+``+"`"+`
+
+`+"`"+`bbox`+"`"+` returns:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  {
+    "t": 0,
+    "dt": 120,
+    "d": {
+      "max_latitude": 40.4263,
+      "max_longitude": -86.9084,
+      "min_latitude": 40.4243,
+      "min_longitude": -86.9114
+    }
+  }
+]
+`+"`"+``+"`"+``+"`"+`
+
+Null values are skipped, and if there are no locations, `+"`"+`bbox`+"`"+` returns `+"`"+`null`+"`"+`. The longitudes are not wrapped around the 180th meridian, so locations on both sides of it give a box that spans the rest of the globe.
+`)
+
+func docsTransformsBboxMdBytes() ([]byte, error) {
+	return _docsTransformsBboxMd, nil
+}
+
+func docsTransformsBboxMd() (*asset, error) {
+	bytes, err := docsTransformsBboxMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/bbox.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _docsTransformsBearingMd = []byte(`The `+"`"+`bearing`+"`"+` transform returns the compass direction from the location of the previous datapoint to each location, in degrees clockwise from north: 0 is north, 90 is east, 180 is south and 270 is west. It expects datapoints with `+"`"+`latitude`+"`"+` and `+"`"+`longitude`+"`"+` fields in decimal coordinates, and gives the initial direction of the shortest path between the two locations.
+
+Given the following locations:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 0, "d": { "latitude": 40.4243, "longitude": -86.9114 } },
+  { "t": 60, "d": { "latitude": 40.4263, "longitude": -86.9114 } },
+  { "t": 120, "d": { "latitude": 40.4263, "longitude": -86.9084 } }
+]
+`+"`"+``+"`"+``+"`"+`
+
+`+"`"+`bearing`+"`"+` returns:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 0, "d": null },
+  { "t": 60, "d": 0 },
+  { "t": 120, "d": 89.99902729592907 }
+]
+`+"`"+``+"`"+``+"`"+`
+
+The first datapoint has no previous location, and a location that is the same as the previous one has no direction, so both are `+"`"+`null`+"`"+`. Null values are skipped, and return `+"`"+`null`+"`"+`.
+`)
+
+func docsTransformsBearingMdBytes() ([]byte, error) {
+	return _docsTransformsBearingMd, nil
+}
+
+func docsTransformsBearingMd() (*asset, error) {
+	bytes, err := docsTransformsBearingMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/bearing.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _docsTransformsBucketMd = []byte(`The bucket transform allows you to put numbers into buckets of custom size.
 
 For example, given this data:
@@ -206,6 +465,48 @@ func docsTransformsBucketMd() (*asset, error) {
 	return a, nil
 }
 
+var _docsTransformsCentroidMd = []byte(`The `+"`"+`centroid`+"`"+` transform returns the average location of the datapoints, as an object with `+"`"+`latitude`+"`"+` and `+"`"+`longitude`+"`"+` fields. It expects datapoints with `+"`"+`latitude`+"`"+` and `+"`"+`longitude`+"`"+` fields in decimal coordinates.
+
+Given the following locations:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 0, "d": { "latitude": 40.4243, "longitude": -86.9114 } },
+  { "t": 60, "d": { "latitude": 40.4263, "longitude": -86.9114 } },
+  { "t": 120, "d": { "latitude": 40.4263, "longitude": -86.9084 } }
+]
+`+"`"+``+"`"+``+"`"+`
+
+`+"`"+`centroid`+"`"+` returns:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  {
+    "t": 0,
+    "dt": 120,
+    "d": { "latitude": 40.42563334194895, "longitude": -86.91040000991161 }
+  }
+]
+`+"`"+``+"`"+``+"`"+`
+
+The locations are averaged as points on the globe, so the centroid of locations on both sides of the 180th meridian is correct. Null values are skipped, and if there are no locations, `+"`"+`centroid`+"`"+` returns `+"`"+`null`+"`"+`.
+`)
+
+func docsTransformsCentroidMdBytes() ([]byte, error) {
+	return _docsTransformsCentroidMd, nil
+}
+
+func docsTransformsCentroidMd() (*asset, error) {
+	bytes, err := docsTransformsCentroidMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/centroid.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _docsTransformsChangedMd = []byte(`The changed transform returns true if the current datapoint's data is different from the previous datapoint.
 
 Given the following data:
@@ -261,6 +562,48 @@ func docsTransformsChangedMd() (*asset, error) {
 	return a, nil
 }
 
+var _docsTransformsCoalesceMd = []byte(`The `+"`"+`coalesce`+"`"+` transform returns the first of its arguments that is not `+"`"+`null`+"`"+`. If all arguments are `+"`"+`null`+"`"+`, the result is `+"`"+`null`+"`"+`.
+
+Given data from two devices that report the same value under different keys:
+
+`+"`"+``+"`"+``+"`"+`json
+[{ "hr": 70 }, { "heartrate": 72 }, {}]
+`+"`"+``+"`"+``+"`"+`
+
+`+"`"+`coalesce(d("hr"), d("heartrate"))`+"`"+` will return:
+
+`+"`"+``+"`"+``+"`"+`json
+[70, 72, null]
+`+"`"+``+"`"+``+"`"+`
+
+Any number of values can be given, and are tried in order: `+"`"+`coalesce(d("a"), d("b"), 0)`+"`"+`.
+
+### Null Values
+
+Data in the real world is often sparse. Rather than failing, PipeScript treats `+"`"+`null`+"`"+` as follows:
+
+- Arithmetic (`+"`"+`+ - * / % ^`+"`"+` and negation) involving `+"`"+`null`+"`"+` gives `+"`"+`null`+"`"+`.
+- The ordering comparisons `+"`"+`<`+"`"+`, `+"`"+`<=`+"`"+`, `+"`"+`>`+"`"+` and `+"`"+`>=`+"`"+` involving `+"`"+`null`+"`"+` are `+"`"+`false`+"`"+`, so `+"`"+`where(d("x") > 5)`+"`"+` skips datapoints without `+"`"+`x`+"`"+`.
+- `+"`"+`==`+"`"+` and `+"`"+`!=`+"`"+` treat `+"`"+`null`+"`"+` as a regular value: `+"`"+`null == null`+"`"+` is `+"`"+`true`+"`"+`.
+- `+"`"+`and`+"`"+`, `+"`"+`or`+"`"+` and `+"`"+`not`+"`"+` treat `+"`"+`null`+"`"+` as `+"`"+`false`+"`"+`.
+- The `+"`"+`sum`+"`"+`, `+"`"+`mean`+"`"+`, `+"`"+`min`+"`"+` and `+"`"+`max`+"`"+` aggregators skip `+"`"+`null`+"`"+` values. The `+"`"+`mean`+"`"+`, `+"`"+`min`+"`"+` and `+"`"+`max`+"`"+` of only `+"`"+`null`+"`"+` values are `+"`"+`null`+"`"+`.
+`)
+
+func docsTransformsCoalesceMdBytes() ([]byte, error) {
+	return _docsTransformsCoalesceMd, nil
+}
+
+func docsTransformsCoalesceMd() (*asset, error) {
+	bytes, err := docsTransformsCoalesceMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/coalesce.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _docsTransformsContainsMd = []byte(``+"`"+`contains`+"`"+` permits you to check if a datapoint with a string data value contains the given substring:
 
 `+"`"+``+"`"+``+"`"+`json
@@ -288,6 +631,44 @@ func docsTransformsContainsMd() (*asset, error) {
 	return a, nil
 }
 
+var _docsTransformsCorrMd = []byte(`The `+"`"+`corr`+"`"+` transform returns the Pearson correlation coefficient of two values of each datapoint, which is between -1 and 1. A value near 1 means that the two increase together, near -1 that one decreases as the other increases, and near 0 that they are not linearly related.
+
+Given the following datapoints:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 0, "d": { "steps": 0, "hr": 62 } },
+  { "t": 60, "d": { "steps": 40, "hr": 75 } },
+  { "t": 120, "d": { "steps": 100, "hr": 90 } },
+  { "t": 180, "d": { "steps": 80, "hr": 88 } },
+  { "t": 240, "d": { "steps": 10, "hr": 70 } }
+]
+`+"`"+``+"`"+``+"`"+`
+
+`+"`"+`corr(d("steps"), d("hr"))`+"`"+` returns:
+
+`+"`"+``+"`"+``+"`"+`json
+[{ "t": 0, "dt": 240, "d": 0.9822294463851707 }]
+`+"`"+``+"`"+``+"`"+`
+
+Datapoints where either value is null are skipped. If there are fewer than two datapoints, or if either value is the same for all datapoints, the correlation is not defined, and `+"`"+`corr`+"`"+` returns `+"`"+`null`+"`"+`. The result is computed in a single pass over the data, so the datapoints are not held in memory. The related `+"`"+`cov`+"`"+` and `+"`"+`linreg`+"`"+` transforms give the covariance and the line that best fits the values.
+`)
+
+func docsTransformsCorrMdBytes() ([]byte, error) {
+	return _docsTransformsCorrMd, nil
+}
+
+func docsTransformsCorrMd() (*asset, error) {
+	bytes, err := docsTransformsCorrMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/corr.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _docsTransformsCountMd = []byte(`Count represents the total number of datapoints passed through the transform. It is equivalent to an `+"`"+`i`+"`"+` used in a loop over an array, with the difference that count starts from 1, rather than 0.
 
 No matter what the datapoints, the sequence of data that count returns is:
@@ -342,63 +723,157 @@ func docsTransformsCountMd() (*asset, error) {
 	return a, nil
 }
 
-var _docsTransformsDMd = []byte(`The identity transform is a placeholder for the "current datapoint". It returns whatever is passed from the timeseries.
+var _docsTransformsCovMd = []byte(`The `+"`"+`cov`+"`"+` transform returns the sample covariance of two values of each datapoint, which is positive when the two values tend to increase together, and negative when one decreases as the other increases.
 
-Suppose your timeseries has the following data:
+Given the following datapoints:
 
 `+"`"+``+"`"+``+"`"+`json
-[44, 18, 20, -35, 20.23]
+[
+  { "t": 0, "d": { "steps": 0, "hr": 62 } },
+  { "t": 60, "d": { "steps": 40, "hr": 75 } },
+  { "t": 120, "d": { "steps": 100, "hr": 90 } },
+  { "t": 180, "d": { "steps": 80, "hr": 88 } },
+  { "t": 240, "d": { "steps": 10, "hr": 70 } }
+]
 `+"`"+``+"`"+``+"`"+`
 
-The d transform will simply return your data unchanged:
+`+"`"+`cov(d("steps"), d("hr"))`+"`"+` returns:
 
 `+"`"+``+"`"+``+"`"+`json
-[44, 18, 20, -35, 20.23]
+[{ "t": 0, "dt": 240, "d": 507.4999999999999 }]
 `+"`"+``+"`"+``+"`"+`
 
-### Usage
+Datapoints where either value is null are skipped, and `+"`"+`cov`+"`"+` returns `+"`"+`null`+"`"+` if there are fewer than two datapoints. Since the covariance depends on the units of the values, the correlation from `+"`"+`corr`+"`"+` is usually easier to interpret.
+`)
 
-The identity transform is perhaps the most used transform in all of pipescript.
-It is frequently used in comparisons and filters
+func docsTransformsCovMdBytes() ([]byte, error) {
+	return _docsTransformsCovMd, nil
+}
 
-For example, the transform `+"`"+`d > 20`+"`"+` is a comparison - it checks whether the current datapoint, represented by the identity is greater than 20. The result of this transform would be:
+func docsTransformsCovMd() (*asset, error) {
+	bytes, err := docsTransformsCovMdBytes()
+	if err != nil {
+		return nil, err
+	}
 
-`+"`"+``+"`"+``+"`"+`json
-[true, false, false, false, true]
-`+"`"+``+"`"+``+"`"+`
+	info := bindataFileInfo{name: "docs/transforms/cov.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
 
-This is frequently used in filters: `+"`"+`where d > 20`+"`"+` would return
+var _docsTransformsCusumMd = []byte(`The `+"`"+`cusum`+"`"+` transform detects when the mean of a stream changes, such as a resting heart rate that slowly increases. It finds the z-score of each value compared to the previous values in its window, and adds up the scores that are above the `+"`"+`drift`+"`"+`, which is 0.5 by default. Separate sums are kept for increases and decreases, and `+"`"+`cusum`+"`"+` returns the larger one, which is negative for decreases. Small changes that `+"`"+`zscore`+"`"+` would not flag add up over time, until the sum becomes large.
+
+Given the following heart rate datapoints:
 
 `+"`"+``+"`"+``+"`"+`json
-[44, 20.23]
+[
+  { "t": 0, "d": 70 },
+  { "t": 60, "d": 72 },
+  { "t": 120, "d": 71 },
+  { "t": 180, "d": 73 },
+  { "t": 240, "d": 120 },
+  { "t": 300, "d": 72 }
+]
 `+"`"+``+"`"+``+"`"+`
 
-### Objects
-
-The d transform accepts an optional argument. Sometimes, a datapoint isn't just your data - it can be an object:
+`+"`"+`cusum`+"`"+` returns:
 
 `+"`"+``+"`"+``+"`"+`json
 [
-  { "hi": "hello", "foo": "bar" },
-  { "hi": "world", "foo": "baz" }
+  { "t": 0, "d": 0 },
+  { "t": 60, "d": 0 },
+  { "t": 120, "d": 0 },
+  { "t": 180, "d": 1.5 },
+  { "t": 240, "d": 38.56793845821194 },
+  { "t": 300, "d": 37.644339494266475 }
 ]
 `+"`"+``+"`"+``+"`"+`
 
-Running this transform:
-
-`+"`"+``+"`"+``+"`"+`javascript
-d("hi");
-`+"`"+``+"`"+``+"`"+`
+If a `+"`"+`threshold`+"`"+` is given, `+"`"+`cusum`+"`"+` returns `+"`"+`true`+"`"+` when a sum goes above it, and then resets the sums to 0 to look for the next change. Otherwise, it returns `+"`"+`false`+"`"+`. A typical threshold is between 4 and 5, such as `+"`"+`cusum(1d, threshold=5)`+"`"+`.
 
-gives us:
+Like `+"`"+`zscore`+"`"+`, the window is a number of seconds, or a number of datapoints with `+"`"+`by="count"`+"`"+`, and 0 uses all previous datapoints. Values are not added to the sums until there are at least two previous values.
+`)
 
-`+"`"+``+"`"+``+"`"+`json
-["hello", "world"]
-`+"`"+``+"`"+``+"`"+`
+func docsTransformsCusumMdBytes() ([]byte, error) {
+	return _docsTransformsCusumMd, nil
+}
 
-### Peeking
+func docsTransformsCusumMd() (*asset, error) {
+	bytes, err := docsTransformsCusumMdBytes()
+	if err != nil {
+		return nil, err
+	}
 
-If given an integer index, the \d transform performs a _peek_ operation - instead of returning the current datapoint, it returns the one at a relative index. For example, you can find the differences between the data of each successive datapoint with the following:
+	info := bindataFileInfo{name: "docs/transforms/cusum.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _docsTransformsDMd = []byte(`The identity transform is a placeholder for the "current datapoint". It returns whatever is passed from the timeseries.
+
+Suppose your timeseries has the following data:
+
+`+"`"+``+"`"+``+"`"+`json
+[44, 18, 20, -35, 20.23]
+`+"`"+``+"`"+``+"`"+`
+
+The d transform will simply return your data unchanged:
+
+`+"`"+``+"`"+``+"`"+`json
+[44, 18, 20, -35, 20.23]
+`+"`"+``+"`"+``+"`"+`
+
+### Usage
+
+The identity transform is perhaps the most used transform in all of pipescript.
+It is frequently used in comparisons and filters
+
+For example, the transform `+"`"+`d > 20`+"`"+` is a comparison - it checks whether the current datapoint, represented by the identity is greater than 20. The result of this transform would be:
+
+`+"`"+``+"`"+``+"`"+`json
+[true, false, false, false, true]
+`+"`"+``+"`"+``+"`"+`
+
+This is frequently used in filters: `+"`"+`where d > 20`+"`"+` would return
+
+`+"`"+``+"`"+``+"`"+`json
+[44, 20.23]
+`+"`"+``+"`"+``+"`"+`
+
+Besides `+"`"+`==`+"`"+`, `+"`"+`!=`+"`"+`, `+"`"+`<`+"`"+`, `+"`"+`<=`+"`"+`, `+"`"+`>`+"`"+` and `+"`"+`>=`+"`"+`, comparisons can use:
+
+- `+"`"+`d between 10 and 20`+"`"+`, which includes both ends of the range. `+"`"+`not between`+"`"+` is also available.
+- `+"`"+`d in [1, 2, 3]`+"`"+`, which checks if the data is an element of an array, a key of an object, or part of a string. `+"`"+`not in`+"`"+` is also available.
+- `+"`"+`d =~ "^[a-z]+$"`+"`"+`, which checks if a string matches a regular expression.
+
+Strings are ordered alphabetically, and objects and arrays are equal if all of their elements are equal.
+
+### Objects
+
+The d transform accepts an optional argument. Sometimes, a datapoint isn't just your data - it can be an object:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "hi": "hello", "foo": "bar" },
+  { "hi": "world", "foo": "baz" }
+]
+`+"`"+``+"`"+``+"`"+`
+
+Running this transform:
+
+`+"`"+``+"`"+``+"`"+`javascript
+d("hi");
+`+"`"+``+"`"+``+"`"+`
+
+gives us:
+
+`+"`"+``+"`"+``+"`"+`json
+["hello", "world"]
+`+"`"+``+"`"+``+"`"+`
+
+### Peeking
+
+If given an integer index, the \d transform performs a _peek_ operation - instead of returning the current datapoint, it returns the one at a relative index. For example, you can find the differences between the data of each successive datapoint with the following:
 
 `+"`"+``+"`"+``+"`"+`
 d[1] - d
@@ -426,6 +901,87 @@ func docsTransformsDMd() (*asset, error) {
 	return a, nil
 }
 
+var _docsTransformsDedupMd = []byte(`Devices that re-sync often upload the same datapoints more than once. The `+"`"+`dedup`+"`"+` transform removes these duplicates.
+
+Given the following datapoints:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 1, "d": 5 },
+  { "t": 1, "d": 5 },
+  { "t": 2, "d": 6 }
+]
+`+"`"+``+"`"+``+"`"+`
+
+`+"`"+`dedup`+"`"+` returns:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 1, "d": 5 },
+  { "t": 2, "d": 6 }
+]
+`+"`"+``+"`"+``+"`"+`
+
+By default, datapoints are duplicates if they have the same timestamp and the same data. The first argument gives the value that is compared instead: `+"`"+`dedup(t)`+"`"+` removes all but one datapoint with each timestamp, and `+"`"+`dedup(d("id"))`+"`"+` uses the `+"`"+`id`+"`"+` field of objects.
+
+The `+"`"+`window`+"`"+` argument allows duplicates to have different timestamps. Datapoints are duplicates if they have the same key, and come within `+"`"+`window`+"`"+` seconds of the first datapoint with that key. For example, `+"`"+`dedup(d, 1m)`+"`"+` removes datapoints that repeat a value from less than a minute before.
+
+The `+"`"+`keep`+"`"+` argument chooses which of the duplicates is returned:
+
+- `+"`"+`"first"`+"`"+` (the default) returns the first datapoint.
+- `+"`"+`"last"`+"`"+` returns the last datapoint.
+- `+"`"+`"merge"`+"`"+` combines the fields of object data, with later datapoints taking precedence, and returns the result at the timestamp of the first datapoint.
+
+For example, `+"`"+`dedup(t, keep="merge")`+"`"+` combines the partial objects uploaded for each timestamp. Only datapoints within the window are held in memory.
+`)
+
+func docsTransformsDedupMdBytes() ([]byte, error) {
+	return _docsTransformsDedupMd, nil
+}
+
+func docsTransformsDedupMd() (*asset, error) {
+	bytes, err := docsTransformsDedupMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/dedup.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _docsTransformsDefaultMd = []byte(`The `+"`"+`default`+"`"+` transform replaces `+"`"+`null`+"`"+` data with the value given in its argument. All other data passes through unchanged.
+
+Given the following data:
+
+`+"`"+``+"`"+``+"`"+`json
+[{ "steps": 10 }, { "heartrate": 70 }]
+`+"`"+``+"`"+``+"`"+`
+
+`+"`"+`d("steps") | default(0)`+"`"+` will return:
+
+`+"`"+``+"`"+``+"`"+`json
+[10, 0]
+`+"`"+``+"`"+``+"`"+`
+
+See `+"`"+`coalesce`+"`"+` for how `+"`"+`null`+"`"+` values are handled by the rest of PipeScript.
+`)
+
+func docsTransformsDefaultMdBytes() ([]byte, error) {
+	return _docsTransformsDefaultMd, nil
+}
+
+func docsTransformsDefaultMd() (*asset, error) {
+	bytes, err := docsTransformsDefaultMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/default.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _docsTransformsDistanceMd = []byte(`The datapoint is assumed to have `+"`"+`latitude`+"`"+` and `+"`"+`longitude`+"`"+` fields in decimal coordinates. It returns the distance in meters computed using the [Haversine formula](https://en.wikipedia.org/wiki/Haversine_formula).
 
 `+"`"+``+"`"+``+"`"+`json
@@ -545,6 +1101,75 @@ func docsTransformsFirstMd() (*asset, error) {
 	return a, nil
 }
 
+var _docsTransformsGeohashMd = []byte(`The `+"`"+`geohash`+"`"+` transform encodes a location as a [geohash](https://en.wikipedia.org/wiki/Geohash), which is a short string that identifies a rectangular cell of the globe. Nearby locations usually share a prefix, so geohashes are useful for grouping locations into areas. It expects datapoints with `+"`"+`latitude`+"`"+` and `+"`"+`longitude`+"`"+` fields in decimal coordinates.
+
+Given the following locations:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 0, "d": { "latitude": 40.4243, "longitude": -86.9114 } },
+  { "t": 60, "d": { "latitude": 40.4263, "longitude": -86.9114 } },
+  { "t": 120, "d": { "latitude": 40.4263, "longitude": -86.9084 } }
+]
+`+"`"+``+"`"+``+"`"+`
+
+`+"`"+`geohash`+"`"+` returns:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 0, "d": "dp4jyr109" },
+  { "t": 60, "d": "dp4jyr3h3" },
+  { "t": 120, "d": "dp4jyr7hr" }
+]
+`+"`"+``+"`"+``+"`"+`
+
+The optional argument sets the number of characters, from 1 to 12, which is 9 by default. With 5 characters, each cell is about 5 kilometers wide, and `+"`"+`geohash(5)`+"`"+` returns `+"`"+`"dp4jy"`+"`"+` for all three locations. With 7 characters, cells are about 150 meters wide, and with 9, about 5 meters. Null values return `+"`"+`null`+"`"+`.
+`)
+
+func docsTransformsGeohashMdBytes() ([]byte, error) {
+	return _docsTransformsGeohashMd, nil
+}
+
+func docsTransformsGeohashMd() (*asset, error) {
+	bytes, err := docsTransformsGeohashMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/geohash.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _docsTransformsGroupbyMd = []byte(`The `+"`"+`groupby`+"`"+` transform is another name for `+"`"+`partition`+"`"+`. It splits datapoints by the value of its first argument, runs the pipe in its second argument separately for each value, and returns all of their outputs in order of their timestamps.
+
+For example, with datapoints that have `+"`"+`id`+"`"+` and `+"`"+`v`+"`"+` fields, `+"`"+`groupby(d("id"), d("v"):changed)`+"`"+` returns whether each `+"`"+`v`+"`"+` changed since the previous datapoint with the same `+"`"+`id`+"`"+`, and `+"`"+`groupby(d("id"), d("v"):sum, tag=true)`+"`"+` returns the sum of `+"`"+`v`+"`"+` for each `+"`"+`id`+"`"+`, as objects with the `+"`"+`key`+"`"+` and the `+"`"+`data`+"`"+` of each sum:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 1, "d": { "key": "a", "data": 4 } },
+  { "t": 2, "d": { "key": "b", "data": 17 } }
+]
+`+"`"+``+"`"+``+"`"+`
+
+See `+"`"+`partition`+"`"+` for details.
+`)
+
+func docsTransformsGroupbyMdBytes() ([]byte, error) {
+	return _docsTransformsGroupbyMd, nil
+}
+
+func docsTransformsGroupbyMd() (*asset, error) {
+	bytes, err := docsTransformsGroupbyMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/groupby.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _docsTransformsIMd = []byte(`The `+"`"+`i`+"`"+` transform gives the index in the timeseries array, starting with 0
 
 `+"`"+``+"`"+``+"`"+`json
@@ -583,244 +1208,1297 @@ func docsTransformsIMd() (*asset, error) {
 	return a, nil
 }
 
-var _docsTransformsLastMd = []byte(``+"`"+``+"`"+``+"`"+`
-where first or last
-`+"`"+``+"`"+``+"`"+`
+var _docsTransformsIncircleMd = []byte(`The `+"`"+`incircle`+"`"+` transform returns `+"`"+`true`+"`"+` if a location is within the given radius in meters of a latitude/longitude coordinate, and `+"`"+`false`+"`"+` otherwise. It expects datapoints with `+"`"+`latitude`+"`"+` and `+"`"+`longitude`+"`"+` fields in decimal coordinates.
 
-will return the first and last datapoint in your stream:
+Given the following locations:
 
 `+"`"+``+"`"+``+"`"+`json
-[1, 2, 3, 4, 5]
+[
+  { "t": 0, "d": { "latitude": 40.4243, "longitude": -86.9114 } },
+  { "t": 60, "d": { "latitude": 40.4263, "longitude": -86.9114 } },
+  { "t": 120, "d": { "latitude": 40.4263, "longitude": -86.9084 } }
+]
 `+"`"+``+"`"+``+"`"+`
 
+`+"`"+`incircle(40.4263, -86.91, 200)`+"`"+` checks which locations are within 200 meters of the coordinate, and returns:
+
 `+"`"+``+"`"+``+"`"+`json
-[1, 5]
+[
+  { "t": 0, "d": false },
+  { "t": 60, "d": true },
+  { "t": 120, "d": true }
+]
 `+"`"+``+"`"+``+"`"+`
+
+Null values return `+"`"+`null`+"`"+`. To keep only the datapoints in the circle, use `+"`"+`where(incircle(40.4263, -86.91, 200))`+"`"+`. For areas that are not circles, use `+"`"+`ingeofence`+"`"+`.
 `)
 
-func docsTransformsLastMdBytes() ([]byte, error) {
-	return _docsTransformsLastMd, nil
+func docsTransformsIncircleMdBytes() ([]byte, error) {
+	return _docsTransformsIncircleMd, nil
 }
 
-func docsTransformsLastMd() (*asset, error) {
-	bytes, err := docsTransformsLastMdBytes()
+func docsTransformsIncircleMd() (*asset, error) {
+	bytes, err := docsTransformsIncircleMdBytes()
 	if err != nil {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "docs/transforms/last.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	info := bindataFileInfo{name: "docs/transforms/incircle.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _docsTransformsMapMd = []byte(`The map transform is an example of a transform which hijacks its second argument. Please note that it is only lightly related to the standard map function in most programming languages. It splits datapoints along its first argument, and runs independent scripts on each value:
+var _docsTransformsIngeofenceMd = []byte(`The `+"`"+`ingeofence`+"`"+` transform returns `+"`"+`true`+"`"+` if a location is inside a polygon, and `+"`"+`false`+"`"+` otherwise. The polygon is given as an array of its corners, each of which is a `+"`"+`[latitude, longitude]`+"`"+` array. It expects datapoints with `+"`"+`latitude`+"`"+` and `+"`"+`longitude`+"`"+` fields in decimal coordinates.
 
+Given the following locations:
 
-Suppose your data stream is the following:
 `+"`"+``+"`"+``+"`"+`json
-[{
-  "steps": 14,
-  "activity": "walking"
-},{
-  "steps": 10,
-  "activity": "running"
-},{
-  "steps": 12,
-  "activity": "walking"
-},{
-  "steps": 5,
-  "activity": "running"
-}]
+[
+  { "t": 0, "d": { "latitude": 40.4243, "longitude": -86.9114 } },
+  { "t": 60, "d": { "latitude": 40.4263, "longitude": -86.9114 } },
+  { "t": 120, "d": { "latitude": 40.4263, "longitude": -86.9084 } }
+]
 `+"`"+``+"`"+``+"`"+`
 
+`+"`"+`ingeofence([[40.425, -86.912], [40.427, -86.912], [40.427, -86.905], [40.425, -86.905]])`+"`"+` returns:
 
-Mapping by activity:
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 0, "d": false },
+  { "t": 60, "d": true },
+  { "t": 120, "d": true }
+]
 `+"`"+``+"`"+``+"`"+`
-map(d("activity"), d("steps"):sum )
+
+The edges of the polygon are straight lines between the latitudes and longitudes of the corners, which is accurate for areas such as buildings and neighborhoods, but not for polygons that cross the 180th meridian or contain a pole. Null values return `+"`"+`null`+"`"+`.
+`)
+
+func docsTransformsIngeofenceMdBytes() ([]byte, error) {
+	return _docsTransformsIngeofenceMd, nil
+}
+
+func docsTransformsIngeofenceMd() (*asset, error) {
+	bytes, err := docsTransformsIngeofenceMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/ingeofence.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _docsTransformsIntMd = []byte(`The `+"`"+`int`+"`"+` transform converts data to an integer. It is one of a family of conversion transforms which can be used to normalize data types at the start of a pipe, such as the all-string rows of a CSV file:
+
+| Transform        | Converts                                                                            |
+| ---------------- | ----------------------------------------------------------------------------------- |
+| `+"`"+`int`+"`"+`            | numbers (truncating towards zero), booleans (`+"`"+`0`+"`"+`/`+"`"+`1`+"`"+`) and numeric strings           |
+| `+"`"+`float`+"`"+`          | numbers, booleans (`+"`"+`0`+"`"+`/`+"`"+`1`+"`"+`) and numeric strings                                     |
+| `+"`"+`string`+"`"+`         | anything. Arrays and objects are encoded as JSON                                    |
+| `+"`"+`bool`+"`"+`           | booleans, numbers (non-zero is `+"`"+`true`+"`"+`) and strings such as `+"`"+`"true"`+"`"+`, `+"`"+`"f"`+"`"+` or `+"`"+`"1"`+"`"+` |
+| `+"`"+`json_parse`+"`"+`     | strings containing JSON                                                             |
+| `+"`"+`json_stringify`+"`"+` | anything, encoding it as JSON                                                       |
+
+`+"`"+`null`+"`"+` data always gives `+"`"+`null`+"`"+`. `+"`"+`typeof`+"`"+` returns the type of the data as a string.
+
+Given the following data:
+
+`+"`"+``+"`"+``+"`"+`json
+[{ "steps": "12" }, { "steps": "3.7" }, { "steps": "" }]
 `+"`"+``+"`"+``+"`"+`
 
-gives you
+`+"`"+`d("steps") | int`+"`"+` will give an error on the third datapoint, since the empty string is not a number.
+Each conversion transform takes an optional `+"`"+`strict`+"`"+` argument, which is `+"`"+`true`+"`"+` by default. When it is `+"`"+`false`+"`"+`, data which can't be converted is replaced with `+"`"+`null`+"`"+`, so `+"`"+`d("steps") | int(false)`+"`"+` returns:
+
 `+"`"+``+"`"+``+"`"+`json
-[{
-  "walking": 26,
-  "running": 15
-}]
+[12, 3, null]
 `+"`"+``+"`"+``+"`"+`
+`)
 
-The map transform split the dataset by its first argument (`+"`"+`d("activity")`+"`"+`), and performed the second transform (`+"`"+`d("steps"):sum`+"`"+`) on each subset independently. This allows directly returning the sum of datapoints where you were walking and running.
+func docsTransformsIntMdBytes() ([]byte, error) {
+	return _docsTransformsIntMd, nil
+}
 
-The map transform is frequently used in conjunction with the `+"`"+`reduce`+"`"+` transform for quick analysis.
+func docsTransformsIntMd() (*asset, error) {
+	bytes, err := docsTransformsIntMdBytes()
+	if err != nil {
+		return nil, err
+	}
 
-Very common use for the map transform is splitting by time periods. For example, to get the total number of steps taken per weekday (suppose steps is a number stream, where the data value is number of steps taken)
+	info := bindataFileInfo{name: "docs/transforms/int.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
 
-`+"`"+``+"`"+``+"`"+`
-map(weekday,sum)
-`+"`"+``+"`"+``+"`"+`
+var _docsTransformsIqr_outlierMd = []byte(`The `+"`"+`iqr_outlier`+"`"+` transform returns `+"`"+`true`+"`"+` for values that are far outside the typical range of the previous values, using Tukey's fences. A value is an outlier if it is more than 1.5 times the interquartile range (the difference between the 75th and 25th percentiles) above the 75th percentile or below the 25th percentile of the previous values in its window.
 
-One issue with the above transform is that it gives a total sum of all datapoints. We
-might want to compute the *average* for a weekday
+Given the following heart rate datapoints:
 
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 0, "d": 70 },
+  { "t": 60, "d": 72 },
+  { "t": 120, "d": 71 },
+  { "t": 180, "d": 73 },
+  { "t": 240, "d": 120 },
+  { "t": 300, "d": 72 }
+]
 `+"`"+``+"`"+``+"`"+`
-map(weekday, imap(day,sum):reduce(mean))
+
+`+"`"+`iqr_outlier`+"`"+` returns:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 0, "d": false },
+  { "t": 60, "d": false },
+  { "t": 120, "d": false },
+  { "t": 180, "d": false },
+  { "t": 240, "d": true },
+  { "t": 300, "d": false }
+]
 `+"`"+``+"`"+``+"`"+`
 
-Note the imap transform within the map. It functions in exactly the same way as map, but returns all intermediate values (which allows it to be embedded in map). The above transform first splits by weekday, then further splits by day. It sums up the steps taken per day and finds their mean. That leaves with daily averages per weekday.
+The `+"`"+`threshold`+"`"+` argument sets the multiple of the range, such as `+"`"+`iqr_outlier(threshold=3)`+"`"+` for only extreme outliers. With `+"`"+`threshold=null`+"`"+`, it returns the number of ranges that the value is above the 75th percentile, or below the 25th percentile as a negative number, and 0 for values between them. The score is `+"`"+`null`+"`"+` until there are at least two previous values, and also when the range is 0 and the value is outside of it. In both cases, the value is not an outlier with a threshold.
 
-Another way to perform the same calculation is:
+Like `+"`"+`zscore`+"`"+`, the window is a number of seconds, or a number of datapoints with `+"`"+`by="count"`+"`"+`, and 0 uses all previous datapoints. The values of the window are kept in memory, so large windows of frequent data use more memory than `+"`"+`zscore`+"`"+`.
+`)
+
+func docsTransformsIqr_outlierMdBytes() ([]byte, error) {
+	return _docsTransformsIqr_outlierMd, nil
+}
+
+func docsTransformsIqr_outlierMd() (*asset, error) {
+	bytes, err := docsTransformsIqr_outlierMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/iqr_outlier.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _docsTransformsIsnullMd = []byte(`The `+"`"+`isnull`+"`"+` transform returns `+"`"+`true`+"`"+` if the datapoint's data is `+"`"+`null`+"`"+`, and `+"`"+`false`+"`"+` otherwise.
+
+Getting a key that does not exist in an object gives `+"`"+`null`+"`"+`, so `+"`"+`isnull`+"`"+` is a simple way to check for missing fields:
+
+`+"`"+``+"`"+``+"`"+`json
+[{ "steps": 10 }, { "heartrate": 70 }]
+`+"`"+``+"`"+``+"`"+`
+
+`+"`"+`d("steps") | isnull`+"`"+` will return:
+
+`+"`"+``+"`"+``+"`"+`json
+[false, true]
+`+"`"+``+"`"+``+"`"+`
+`)
+
+func docsTransformsIsnullMdBytes() ([]byte, error) {
+	return _docsTransformsIsnullMd, nil
+}
+
+func docsTransformsIsnullMd() (*asset, error) {
+	bytes, err := docsTransformsIsnullMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/isnull.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _docsTransformsJson_parseMd = []byte(`The `+"`"+`json_parse`+"`"+` transform parses strings containing JSON, allowing values which were encoded as text to be used with the rest of PipeScript.
+
+Given the following data:
+
+`+"`"+``+"`"+``+"`"+`json
+["{\"a\": 1}", "[1,2]", "oops"]
+`+"`"+``+"`"+``+"`"+`
+
+`+"`"+`json_parse(false)`+"`"+` will return:
+
+`+"`"+``+"`"+``+"`"+`json
+[{ "a": 1 }, [1, 2], null]
+`+"`"+``+"`"+``+"`"+`
+
+With the default `+"`"+`json_parse`+"`"+`, the third datapoint would give an error. `+"`"+`json_stringify`+"`"+` does the reverse, encoding data as a JSON string.
+`)
+
+func docsTransformsJson_parseMdBytes() ([]byte, error) {
+	return _docsTransformsJson_parseMd, nil
+}
+
+func docsTransformsJson_parseMd() (*asset, error) {
+	bytes, err := docsTransformsJson_parseMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/json_parse.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _docsTransformsLastMd = []byte(``+"`"+``+"`"+``+"`"+`
+where first or last
+`+"`"+``+"`"+``+"`"+`
+
+will return the first and last datapoint in your stream:
+
+`+"`"+``+"`"+``+"`"+`json
+[1, 2, 3, 4, 5]
+`+"`"+``+"`"+``+"`"+`
+
+`+"`"+``+"`"+``+"`"+`json
+[1, 5]
+`+"`"+``+"`"+``+"`"+`
+`)
+
+func docsTransformsLastMdBytes() ([]byte, error) {
+	return _docsTransformsLastMd, nil
+}
+
+func docsTransformsLastMd() (*asset, error) {
+	bytes, err := docsTransformsLastMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/last.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _docsTransformsLevenshteinMd = []byte(`The `+"`"+`levenshtein`+"`"+` transform returns the [Levenshtein distance](https://en.wikipedia.org/wiki/Levenshtein_distance) between two strings, which is the number of characters that need to be inserted, deleted or replaced to change one into the other. It is useful for finding strings that differ only by typos.
+
+Given the following data:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 1, "d": "kitten" },
+  { "t": 2, "d": "mitten" },
+  { "t": 3, "d": "sitting" }
+]
+`+"`"+``+"`"+``+"`"+`
+
+`+"`"+`levenshtein('kitten')`+"`"+` returns:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 1, "d": 0 },
+  { "t": 2, "d": 1 },
+  { "t": 3, "d": 3 }
+]
+`+"`"+``+"`"+``+"`"+`
+
+The string that is compared is the datapoint's data by default, and can be given as the second argument to compare two fields of an object. For example, `+"`"+`levenshtein(d("typed"), d("expected"))`+"`"+` compares the `+"`"+`typed`+"`"+` and `+"`"+`expected`+"`"+` fields of each datapoint. If either string is null, it returns `+"`"+`null`+"`"+`. To compare strings of different lengths, use `+"`"+`similarity`+"`"+`.
+`)
+
+func docsTransformsLevenshteinMdBytes() ([]byte, error) {
+	return _docsTransformsLevenshteinMd, nil
+}
+
+func docsTransformsLevenshteinMd() (*asset, error) {
+	bytes, err := docsTransformsLevenshteinMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/levenshtein.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _docsTransformsLinregMd = []byte(`The `+"`"+`linreg`+"`"+` transform finds the line `+"`"+`y = slope*x + intercept`+"`"+` that best fits two values of each datapoint, using least squares. It returns the `+"`"+`slope`+"`"+` and `+"`"+`intercept`+"`"+` of the line, and `+"`"+`r2`+"`"+`, the fraction of the variation of `+"`"+`y`+"`"+` that is explained by the line, which is between 0 and 1.
+
+Given the following datapoints:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 0, "d": { "steps": 0, "hr": 62 } },
+  { "t": 60, "d": { "steps": 40, "hr": 75 } },
+  { "t": 120, "d": { "steps": 100, "hr": 90 } },
+  { "t": 180, "d": { "steps": 80, "hr": 88 } },
+  { "t": 240, "d": { "steps": 10, "hr": 70 } }
+]
+`+"`"+``+"`"+``+"`"+`
+
+`+"`"+`linreg(d("steps"), d("hr"))`+"`"+` returns:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  {
+    "t": 0,
+    "dt": 240,
+    "d": {
+      "intercept": 64.58244680851064,
+      "r2": 0.9647746853461191,
+      "slope": 0.2699468085106383
+    }
+  }
+]
+`+"`"+``+"`"+``+"`"+`
+
+Here, each step per minute is associated with a heart rate that is 0.27 beats per minute higher.
+
+Datapoints where either value is null are skipped. If there are fewer than two datapoints, or all `+"`"+`x`+"`"+` values are the same, there is no single best line, and `+"`"+`linreg`+"`"+` returns `+"`"+`null`+"`"+`. To fit a value against time, use `+"`"+`trend`+"`"+`.
+`)
+
+func docsTransformsLinregMdBytes() ([]byte, error) {
+	return _docsTransformsLinregMd, nil
+}
+
+func docsTransformsLinregMd() (*asset, error) {
+	bytes, err := docsTransformsLinregMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/linreg.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _docsTransformsMad_zscoreMd = []byte(`The `+"`"+`mad_zscore`+"`"+` transform returns the modified z-score of each value, which is based on the median and the median absolute deviation (MAD) of the previous values in its window, rather than the mean and standard deviation. Unlike `+"`"+`zscore`+"`"+`, a few large outliers barely change the score of later values. The score is scaled to be similar to a z-score for normally distributed data, and a common threshold for outliers is 3.5.
+
+Given the following heart rate datapoints:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 0, "d": 70 },
+  { "t": 60, "d": 72 },
+  { "t": 120, "d": 71 },
+  { "t": 180, "d": 73 },
+  { "t": 240, "d": 120 },
+  { "t": 300, "d": 72 }
+]
+`+"`"+``+"`"+``+"`"+`
+
+`+"`"+`mad_zscore`+"`"+` returns:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 0, "d": null },
+  { "t": 60, "d": null },
+  { "t": 120, "d": 0 },
+  { "t": 180, "d": 1.349 },
+  { "t": 240, "d": 32.71325 },
+  { "t": 300, "d": 0 }
+]
+`+"`"+``+"`"+``+"`"+`
+
+`+"`"+`mad_zscore(threshold=3.5)`+"`"+` returns `+"`"+`true`+"`"+` for the values whose score is further than 3.5 from 0, and `+"`"+`false`+"`"+` otherwise, including when the score is `+"`"+`null`+"`"+`. The score is `+"`"+`null`+"`"+` until there are at least two previous values, and also when at least half of the previous values are the same, and the current value is different.
+
+Like `+"`"+`zscore`+"`"+`, the window is a number of seconds, or a number of datapoints with `+"`"+`by="count"`+"`"+`, and 0 uses all previous datapoints. The values of the window are kept in memory.
+`)
+
+func docsTransformsMad_zscoreMdBytes() ([]byte, error) {
+	return _docsTransformsMad_zscoreMd, nil
+}
+
+func docsTransformsMad_zscoreMd() (*asset, error) {
+	bytes, err := docsTransformsMad_zscoreMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/mad_zscore.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _docsTransformsMapMd = []byte(`The map transform is an example of a transform which hijacks its second argument. Please note that it is only lightly related to the standard map function in most programming languages. It splits datapoints along its first argument, and runs independent scripts on each value:
+
+
+Suppose your data stream is the following:
+`+"`"+``+"`"+``+"`"+`json
+[{
+  "steps": 14,
+  "activity": "walking"
+},{
+  "steps": 10,
+  "activity": "running"
+},{
+  "steps": 12,
+  "activity": "walking"
+},{
+  "steps": 5,
+  "activity": "running"
+}]
+`+"`"+``+"`"+``+"`"+`
+
+
+Mapping by activity:
+`+"`"+``+"`"+``+"`"+`
+map(d("activity"), d("steps"):sum )
+`+"`"+``+"`"+``+"`"+`
+
+gives you
+`+"`"+``+"`"+``+"`"+`json
+[{
+  "walking": 26,
+  "running": 15
+}]
+`+"`"+``+"`"+``+"`"+`
+
+The map transform split the dataset by its first argument (`+"`"+`d("activity")`+"`"+`), and performed the second transform (`+"`"+`d("steps"):sum`+"`"+`) on each subset independently. This allows directly returning the sum of datapoints where you were walking and running.
+
+The map transform is frequently used in conjunction with the `+"`"+`reduce`+"`"+` transform for quick analysis.
+
+The map transform only returns its result once all of the data was seen. To instead get the outputs of the script for each value as they happen, such as whether a value `+"`"+`changed`+"`"+` since the previous datapoint with the same key, use the `+"`"+`partition`+"`"+` transform.
+
+Very common use for the map transform is splitting by time periods. For example, to get the total number of steps taken per weekday (suppose steps is a number stream, where the data value is number of steps taken)
+
+`+"`"+``+"`"+``+"`"+`
+map(weekday,sum)
+`+"`"+``+"`"+``+"`"+`
+
+One issue with the above transform is that it gives a total sum of all datapoints. We
+might want to compute the *average* for a weekday
+
+`+"`"+``+"`"+``+"`"+`
+map(weekday, imap(day,sum):reduce(mean))
+`+"`"+``+"`"+``+"`"+`
+
+Note the imap transform within the map. It functions in exactly the same way as map, but returns all intermediate values (which allows it to be embedded in map). The above transform first splits by weekday, then further splits by day. It sums up the steps taken per day and finds their mean. That leaves with daily averages per weekday.
+
+Another way to perform the same calculation is:
+
+`+"`"+``+"`"+``+"`"+`
+while(day==next:day,sum) | map(weekday,mean)
+`+"`"+``+"`"+``+"`"+`
+
+This sums up datapoints for each day, and only maps the summed datapoints. This is the recommended method for such computations.
+`)
+
+func docsTransformsMapMdBytes() ([]byte, error) {
+	return _docsTransformsMapMd, nil
+}
+
+func docsTransformsMapMd() (*asset, error) {
+	bytes, err := docsTransformsMapMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/map.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _docsTransformsMeanMd = []byte(`### Input
+
+`+"`"+``+"`"+``+"`"+`json
+[1, 2, 6]
+`+"`"+``+"`"+``+"`"+`
+
+### Output
+
+`+"`"+``+"`"+``+"`"+`json
+[3]
+`+"`"+``+"`"+``+"`"+`
+`)
+
+func docsTransformsMeanMdBytes() ([]byte, error) {
+	return _docsTransformsMeanMd, nil
+}
+
+func docsTransformsMeanMd() (*asset, error) {
+	bytes, err := docsTransformsMeanMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/mean.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _docsTransformsNgramsMd = []byte(`The `+"`"+`ngrams`+"`"+` transform returns all sequences of `+"`"+`n`+"`"+` consecutive words in the text, each joined by spaces. By default, `+"`"+`n`+"`"+` is 2, giving the pairs of words that follow each other. It accepts either a string, which is split into lowercase words like in `+"`"+`tokens`+"`"+`, or an array of words.
+
+Given the following data:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 1, "d": "The coffee is great" },
+  { "t": 2, "d": "Coffee" }
+]
+`+"`"+``+"`"+``+"`"+`
+
+`+"`"+`ngrams`+"`"+` returns:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 1, "d": ["the coffee", "coffee is", "is great"] },
+  { "t": 2, "d": [] }
+]
+`+"`"+``+"`"+``+"`"+`
+
+`+"`"+`ngrams(3)`+"`"+` instead returns `+"`"+`["the coffee is", "coffee is great"]`+"`"+` for the first datapoint. To leave out common words, split the text with `+"`"+`tokens`+"`"+` first, such as `+"`"+`tokens(stopwords=true):ngrams(2)`+"`"+`, which returns `+"`"+`["coffee great"]`+"`"+`. Null values return `+"`"+`null`+"`"+`.
+`)
+
+func docsTransformsNgramsMdBytes() ([]byte, error) {
+	return _docsTransformsNgramsMd, nil
+}
+
+func docsTransformsNgramsMd() (*asset, error) {
+	bytes, err := docsTransformsNgramsMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/ngrams.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _docsTransformsPartitionMd = []byte(`The `+"`"+`partition`+"`"+` transform splits datapoints by the value of its first argument, and runs the pipe in its second argument separately for each value. Unlike `+"`"+`map`+"`"+`, which returns a single object once all of the data was seen, `+"`"+`partition`+"`"+` returns the outputs of all of the pipes as they happen, in order of their timestamps. This allows running transforms that compare each datapoint with the previous one, such as `+"`"+`changed`+"`"+`, separately for each device or user.
+
+Suppose your data stream contains readings from two devices:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 1, "d": { "id": "a", "v": 1 } },
+  { "t": 2, "d": { "id": "b", "v": 5 } },
+  { "t": 3, "d": { "id": "a", "v": 1 } },
+  { "t": 4, "d": { "id": "b", "v": 6 } },
+  { "t": 5, "d": { "id": "a", "v": 2 } },
+  { "t": 6, "d": { "id": "b", "v": 6 } }
+]
+`+"`"+``+"`"+``+"`"+`
+
+Running `+"`"+`partition(d("id"), d("v"):changed)`+"`"+` checks whether each reading changed since the previous reading of the same device:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 1, "d": true },
+  { "t": 2, "d": true },
+  { "t": 3, "d": false },
+  { "t": 4, "d": true },
+  { "t": 5, "d": true },
+  { "t": 6, "d": false }
+]
+`+"`"+``+"`"+``+"`"+`
+
+To know which value each output came from, set the optional third argument, `+"`"+`tag`+"`"+`, to true. Each output is then an object with the `+"`"+`key`+"`"+` and the `+"`"+`data`+"`"+` of the output. For example, `+"`"+`partition(d("id"), d("v"):sum, tag=true)`+"`"+` returns:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 1, "d": { "key": "a", "data": 4 } },
+  { "t": 2, "d": { "key": "b", "data": 17 } }
+]
+`+"`"+``+"`"+``+"`"+`
+
+Each output is returned as soon as no other pipe can return an earlier one, and outputs with the same timestamp are returned in the order that their keys were first seen. Pipes that return one datapoint for each input, like `+"`"+`changed`+"`"+`, are returned right away. Other pipes are assumed to return datapoints with the timestamps of their inputs, like `+"`"+`where`+"`"+`, or of the first datapoint of a group, like `+"`"+`while`+"`"+`, so a pipe holds back outputs of other pipes that come after the oldest datapoint it has not returned yet. Aggregators like `+"`"+`sum`+"`"+` only return once all of the data was seen, so outputs of other pipes that come after their first datapoint are held until then.
+
+The `+"`"+`groupby`+"`"+` transform is another name for `+"`"+`partition`+"`"+`.
+`)
+
+func docsTransformsPartitionMdBytes() ([]byte, error) {
+	return _docsTransformsPartitionMd, nil
+}
+
+func docsTransformsPartitionMd() (*asset, error) {
+	bytes, err := docsTransformsPartitionMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/partition.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _docsTransformsPathlengthMd = []byte(`The `+"`"+`pathlength`+"`"+` transform returns the total distance in meters traveled through the locations of the datapoints, in order. It expects datapoints with `+"`"+`latitude`+"`"+` and `+"`"+`longitude`+"`"+` fields in decimal coordinates, and adds up the distance between each location and the next, found with the [Haversine formula](https://en.wikipedia.org/wiki/Haversine_formula).
+
+Given the following locations:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 0, "d": { "latitude": 40.4243, "longitude": -86.9114 } },
+  { "t": 60, "d": { "latitude": 40.4263, "longitude": -86.9114 } },
+  { "t": 120, "d": { "latitude": 40.4263, "longitude": -86.9084 } }
+]
+`+"`"+``+"`"+``+"`"+`
+
+`+"`"+`pathlength`+"`"+` returns:
+
+`+"`"+``+"`"+``+"`"+`json
+[{ "t": 0, "dt": 120, "d": 476.3281734324868 }]
+`+"`"+``+"`"+``+"`"+`
+
+Null values are skipped. Since the distance is measured between the recorded locations, noisy locations make the path longer, and infrequent locations make it shorter than the path that was actually traveled.
+`)
+
+func docsTransformsPathlengthMdBytes() ([]byte, error) {
+	return _docsTransformsPathlengthMd, nil
+}
+
+func docsTransformsPathlengthMd() (*asset, error) {
+	bytes, err := docsTransformsPathlengthMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/pathlength.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _docsTransformsPatternMd = []byte(`The `+"`"+`pattern`+"`"+` transform finds sequences of events, such as "the phone was unlocked, then an app was opened, then the phone was locked within 2 minutes". It takes an array of conditions, and finds datapoints for which the conditions are true, in order. Other datapoints can come between the steps of a match.
+
+Given the following datapoints:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 0, "d": "unlocked" },
+  { "t": 10, "d": "app" },
+  { "t": 30, "d": "locked" },
+  { "t": 100, "d": "unlocked" },
+  { "t": 300, "d": "locked" }
+]
+`+"`"+``+"`"+``+"`"+`
+
+`+"`"+`pattern([d=="unlocked", d=="app", d=="locked"])`+"`"+` returns one datapoint for each match, with the timestamp of the first step, a duration until the end of the last step, and an array of the matched data:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 0, "dt": 30, "d": ["unlocked", "app", "locked"] }
+]
+`+"`"+``+"`"+``+"`"+`
+
+The optional arguments limit the time of a match:
+
+- `+"`"+`within`+"`"+` is the maximum number of seconds from the first to the last step. `+"`"+`pattern([d=="unlocked", d=="locked"], within=2m)`+"`"+` only returns the first unlock above, since the phone was locked 200 seconds after the second one.
+- `+"`"+`gap`+"`"+` is the maximum number of seconds between a step and the next one. It can also be an array with a limit after each step, such as `+"`"+`gap=[1m, 5m]`+"`"+` for a pattern with 3 steps. A limit of 0 means there is no limit.
+- `+"`"+`absent`+"`"+` is a condition that must not be true for any datapoint between the first and last step, which gives patterns where an event is not followed by another. `+"`"+`pattern([d=="unlocked", d=="locked"], absent=d=="app")`+"`"+` finds the times the phone was unlocked and then locked without opening an app, returning the second unlock above. It can also be an array with a condition for each step, which must not be true after that step until the next one, such as `+"`"+`absent=[d=="app", false]`+"`"+`.
+- `+"`"+`timeout`+"`"+` is a number of seconds after the last step during which the last condition in the `+"`"+`absent`+"`"+` array must not be true. A match is then only returned once the timeout has passed, when the first datapoint after it arrives, or at the end of the data. This finds events that are not followed by another: `+"`"+`pattern([d=="unlocked"], absent=[d=="app"], timeout=1m)`+"`"+` returns the times the phone was unlocked without an app being opened in the next minute, which is the second unlock above.
+
+The number of `+"`"+`gap`+"`"+` limits and `+"`"+`absent`+"`"+` conditions must match the number of steps, which is checked when the query is parsed if the steps are given as an array.
+
+Matches do not overlap, and each one is returned as soon as its last step is found, unless there is a timeout. When several datapoints could be the start of a match, the latest one is used, so the shortest match is returned.
+`)
+
+func docsTransformsPatternMdBytes() ([]byte, error) {
+	return _docsTransformsPatternMd, nil
+}
+
+func docsTransformsPatternMd() (*asset, error) {
+	bytes, err := docsTransformsPatternMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/pattern.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _docsTransformsPickMd = []byte(`The `+"`"+`pick`+"`"+` transform returns an object with only the given keys. Keys that are missing from the datapoint are not added to the output.
+
+Given the following data:
+
+`+"`"+``+"`"+``+"`"+`json
+[{ "steps": 10, "device": "phone", "battery": 0.5 }]
+`+"`"+``+"`"+``+"`"+`
+
+`+"`"+`pick("steps")`+"`"+` will return:
+
+`+"`"+``+"`"+``+"`"+`json
+[{ "steps": 10 }]
+`+"`"+``+"`"+``+"`"+`
+
+Several keys can be given at once, either as separate args, `+"`"+`pick("steps", "device")`+"`"+`, or as an array, `+"`"+`pick(["steps", "device"])`+"`"+`.
+
+The `+"`"+`omit`+"`"+` transform does the opposite, and returns the object without the given keys.
+`)
+
+func docsTransformsPickMdBytes() ([]byte, error) {
+	return _docsTransformsPickMd, nil
+}
+
+func docsTransformsPickMd() (*asset, error) {
+	bytes, err := docsTransformsPickMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/pick.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _docsTransformsReduceMd = []byte(``+"`"+`reduce`+"`"+` performs a given transform on all the elements of a multi-element datapoint.
+
+Suppose you have the following data:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  {
+    "a": 3,
+    "b": 5
+  },
+  {
+    "q": 10,
+    "z": 23
+  }
+]
+`+"`"+``+"`"+``+"`"+`
+
+Then the transform `+"`"+`reduce(sum)`+"`"+` will give you:
+
+`+"`"+``+"`"+``+"`"+`json
+[8, 33]
+`+"`"+``+"`"+``+"`"+`
+
+Reduce took each element in the given datapoint, and applied the transform `+"`"+`sum`+"`"+` to it.
+
+## Usage
+
+The reduce transform is particularly useful in conjunction with the `+"`"+`map`+"`"+` transform.
+
+Suppose you want to find the average number of steps taken every weekday.
+
+Running the following transform will give you a map of weekday to average step count:
+
+`+"`"+``+"`"+``+"`"+`
+while(day==d[1]:day,sum) | map(weekday, mean)
+`+"`"+``+"`"+``+"`"+`
+
+For example, a possible result of the above transform could be:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  {
+    "Monday": 12243,
+    "Tuesday": 13452,
+    "Wednesday": 14523,
+    "Thursday": 9543,
+    "Friday": 20487,
+    "Saturday": 3000,
+    "Sunday": 4000
+  }
+]
+`+"`"+``+"`"+``+"`"+`
+
+You can now find the average per weekday by running `+"`"+`reduce(mean)`+"`"+`, giving a final transform:
+
+`+"`"+``+"`"+``+"`"+`
+while(day==d[1]:day,sum) | map(weekday, mean) | reduce(mean)
+`+"`"+``+"`"+``+"`"+`
+`)
+
+func docsTransformsReduceMdBytes() ([]byte, error) {
+	return _docsTransformsReduceMd, nil
+}
+
+func docsTransformsReduceMd() (*asset, error) {
+	bytes, err := docsTransformsReduceMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/reduce.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _docsTransformsRegexMd = []byte(`The regex transform checks if the data string matches the given regex.
+
+For example, given a regex to check for valid usernames: `+"`"+`regex('^[a-z0-9_-]{3,16}$')`+"`"+`, we get:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  "Hello World!",
+  "valid_username",
+  "1"
+]
+`+"`"+``+"`"+``+"`"+`
+
+`+"`"+``+"`"+``+"`"+`json
+[false,true,false]
+`+"`"+``+"`"+``+"`"+`
+`)
+
+func docsTransformsRegexMdBytes() ([]byte, error) {
+	return _docsTransformsRegexMd, nil
+}
+
+func docsTransformsRegexMd() (*asset, error) {
+	bytes, err := docsTransformsRegexMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/regex.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _docsTransformsRegex_extractMd = []byte(`The `+"`"+`regex_extract`+"`"+` transform returns the part of the data string matched by a regular expression. The regular expression is compiled once, when the transform is created.
+
+By default the full match is returned. The optional second argument picks a capture group, either by its index or by its name. If the regex does not match, the result is `+"`"+`null`+"`"+`.
+
+Given the following data:
+
+`+"`"+``+"`"+``+"`"+`json
+["user=alice id=12", "user=bob", "nothing here"]
+`+"`"+``+"`"+``+"`"+`
+
+`+"`"+`regex_extract('user=(\w+)', 1)`+"`"+` will return:
+
+`+"`"+``+"`"+``+"`"+`json
+["alice", "bob", null]
+`+"`"+``+"`"+``+"`"+`
+
+Named groups work the same way: `+"`"+`regex_extract('id=(?P<id>[0-9]+)', 'id')`+"`"+` returns `+"`"+`["12", null, null]`+"`"+`.
+`)
+
+func docsTransformsRegex_extractMdBytes() ([]byte, error) {
+	return _docsTransformsRegex_extractMd, nil
+}
+
+func docsTransformsRegex_extractMd() (*asset, error) {
+	bytes, err := docsTransformsRegex_extractMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/regex_extract.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _docsTransformsRegex_findallMd = []byte(`The `+"`"+`regex_findall`+"`"+` transform returns an array of all non-overlapping matches of a regular expression in the data string.
+
+Given the following data:
+
+`+"`"+``+"`"+``+"`"+`json
+["#run with #friends", "no tags"]
+`+"`"+``+"`"+``+"`"+`
+
+`+"`"+`regex_findall('#\w+')`+"`"+` will return:
+
+`+"`"+``+"`"+``+"`"+`json
+[["#run", "#friends"], []]
+`+"`"+``+"`"+``+"`"+`
+`)
+
+func docsTransformsRegex_findallMdBytes() ([]byte, error) {
+	return _docsTransformsRegex_findallMd, nil
+}
+
+func docsTransformsRegex_findallMd() (*asset, error) {
+	bytes, err := docsTransformsRegex_findallMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/regex_findall.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _docsTransformsRegex_parseMd = []byte(`The `+"`"+`regex_parse`+"`"+` transform turns log-like strings into structured objects. The regular expression must contain named capture groups, written `+"`"+`(?P<name>...)`+"`"+`. Each named group becomes a key of the output object.
+
+Given the following data:
+
+`+"`"+``+"`"+``+"`"+`json
+["GET /index.html 200", "POST /login 403", "garbage"]
+`+"`"+``+"`"+``+"`"+`
+
+`+"`"+`regex_parse('(?P<method>[A-Z]+) (?P<path>\S+) (?P<status>\d+)')`+"`"+` will return:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "method": "GET", "path": "/index.html", "status": "200" },
+  { "method": "POST", "path": "/login", "status": "403" },
+  null
+]
+`+"`"+``+"`"+``+"`"+`
+
+Groups that did not take part in the match are `+"`"+`null`+"`"+`. If the whole regex does not match, the result is `+"`"+`null`+"`"+`, which makes it easy to filter out lines that are not in the expected format.
+`)
+
+func docsTransformsRegex_parseMdBytes() ([]byte, error) {
+	return _docsTransformsRegex_parseMd, nil
+}
+
+func docsTransformsRegex_parseMd() (*asset, error) {
+	bytes, err := docsTransformsRegex_parseMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/regex_parse.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _docsTransformsRegex_replaceMd = []byte(`The `+"`"+`regex_replace`+"`"+` transform replaces every match of a regular expression in the data string with the given replacement.
+
+Inside the replacement, `+"`"+`$1`+"`"+` or `+"`"+`${name}`+"`"+` inserts the text of a capture group.
+
+Given the following data:
+
+`+"`"+``+"`"+``+"`"+`json
+["2021-03-04", "1999-12-31"]
+`+"`"+``+"`"+``+"`"+`
+
+`+"`"+`regex_replace('(\d+)-(\d+)-(\d+)', '$3/$2/$1')`+"`"+` will return:
+
+`+"`"+``+"`"+``+"`"+`json
+["04/03/2021", "31/12/1999"]
+`+"`"+``+"`"+``+"`"+`
+
+Data that is not a string is passed through unchanged.
+`)
+
+func docsTransformsRegex_replaceMdBytes() ([]byte, error) {
+	return _docsTransformsRegex_replaceMd, nil
+}
+
+func docsTransformsRegex_replaceMd() (*asset, error) {
+	bytes, err := docsTransformsRegex_replaceMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/regex_replace.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _docsTransformsReorderMd = []byte(`PipeScript assumes that datapoints are ordered by timestamp. Data uploaded from phones and other devices often arrives slightly out of order, which silently gives wrong results from transforms such as `+"`"+`while`+"`"+`, and from interpolators. The `+"`"+`reorder`+"`"+` transform fixes this by holding datapoints until it is certain that no earlier datapoint can still arrive, and returning them sorted by timestamp.
+
+Given the following timestamps:
+
+`+"`"+``+"`"+``+"`"+`json
+[1, 3, 2, 5, 4, 10]
+`+"`"+``+"`"+``+"`"+`
+
+`+"`"+`reorder(2)`+"`"+` waits until a datapoint 2 seconds newer has arrived before returning each datapoint, so it returns:
+
+`+"`"+``+"`"+``+"`"+`json
+[1, 2, 3, 4, 5, 10]
+`+"`"+``+"`"+``+"`"+`
+
+Durations are convenient for the maximum lag: `+"`"+`reorder(5m)`+"`"+`. A datapoint that arrives more than the allowed lag after newer datapoints gives an error, rather than being silently put out of order. The optional `+"`"+`count`+"`"+` argument limits the number of datapoints that are held at once, for example `+"`"+`reorder(1h, count=1000)`+"`"+`, and `+"`"+`reorder(0)`+"`"+` sorts the entire stream.
+
+Datapoints with equal timestamps keep their order. When reading files with `+"`"+`pipes run`+"`"+`, the `+"`"+`--reorder`+"`"+` option sorts the input in the same way before it is given to the script.
+
+To check that a stream is already ordered, use `+"`"+`assert_ordered`+"`"+`, which passes through datapoints unchanged, but fails with an error naming the offending timestamps if a datapoint is earlier than the one before it.
+`)
+
+func docsTransformsReorderMdBytes() ([]byte, error) {
+	return _docsTransformsReorderMd, nil
+}
+
+func docsTransformsReorderMd() (*asset, error) {
+	bytes, err := docsTransformsReorderMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/reorder.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _docsTransformsRoundMd = []byte(`The `+"`"+`round`+"`"+` transform rounds numbers to the given number of digits after the decimal point, with halves rounded away from zero.
+
+Given the following data:
+
+`+"`"+``+"`"+``+"`"+`json
+[3.14159, 2.5, -2.5, 7]
+`+"`"+``+"`"+``+"`"+`
+
+`+"`"+`round`+"`"+` will return:
+
+`+"`"+``+"`"+``+"`"+`json
+[3, 3, -3, 7]
+`+"`"+``+"`"+``+"`"+`
+
+and `+"`"+`round(2)`+"`"+` will return:
+
+`+"`"+``+"`"+``+"`"+`json
+[3.14, 2.5, -2.5, 7]
+`+"`"+``+"`"+``+"`"+`
+
+A negative number of digits rounds to the left of the decimal point, so `+"`"+`round(-1)`+"`"+` rounds to the nearest 10. The number of digits can be from -15 to 15.
+
+Like the other math transforms (`+"`"+`abs`+"`"+`, `+"`"+`floor`+"`"+`, `+"`"+`ceil`+"`"+`, `+"`"+`sqrt`+"`"+`, `+"`"+`log`+"`"+`, ...), `+"`"+`round`+"`"+` works on the datapoint's data, and can be used on the result of an expression with a pipe: `+"`"+`(d("x") / 3) | round(1)`+"`"+`. `+"`"+`null`+"`"+` data gives `+"`"+`null`+"`"+`.
+`)
+
+func docsTransformsRoundMdBytes() ([]byte, error) {
+	return _docsTransformsRoundMd, nil
+}
+
+func docsTransformsRoundMd() (*asset, error) {
+	bytes, err := docsTransformsRoundMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/round.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _docsTransformsSetMd = []byte(`The `+"`"+`set`+"`"+` transform sets a key of an object to the value of its second argument. All other fields of the object are kept as they are, so there is no need to rebuild the object with `+"`"+`{...}`+"`"+` to change one field.
+
+Given the following data:
 
+`+"`"+``+"`"+``+"`"+`json
+[{ "steps": 10, "device": "phone" }]
 `+"`"+``+"`"+``+"`"+`
-while(day==next:day,sum) | map(weekday,mean)
+
+`+"`"+`set("steps", d("steps") * 2)`+"`"+` will return:
+
+`+"`"+``+"`"+``+"`"+`json
+[{ "steps": 20, "device": "phone" }]
 `+"`"+``+"`"+``+"`"+`
 
-This sums up datapoints for each day, and only maps the summed datapoints. This is the recommended method for such computations.
+The related transforms `+"`"+`unset`+"`"+`, `+"`"+`pick`+"`"+`, `+"`"+`omit`+"`"+`, `+"`"+`rename`+"`"+` and `+"`"+`merge`+"`"+` also modify objects while keeping their other fields:
+
+- `+"`"+`unset("device")`+"`"+` removes the `+"`"+`device`+"`"+` key.
+- `+"`"+`pick("steps")`+"`"+` keeps only `+"`"+`steps`+"`"+`. `+"`"+`omit("steps")`+"`"+` removes `+"`"+`steps`+"`"+`. Both also accept an array of keys.
+- `+"`"+`rename("steps", "count")`+"`"+` moves the value of `+"`"+`steps`+"`"+` to `+"`"+`count`+"`"+`.
+- `+"`"+`merge({"source": "fitbit"})`+"`"+` adds all fields of the given object, replacing fields that already exist.
 `)
 
-func docsTransformsMapMdBytes() ([]byte, error) {
-	return _docsTransformsMapMd, nil
+func docsTransformsSetMdBytes() ([]byte, error) {
+	return _docsTransformsSetMd, nil
 }
 
-func docsTransformsMapMd() (*asset, error) {
-	bytes, err := docsTransformsMapMdBytes()
+func docsTransformsSetMd() (*asset, error) {
+	bytes, err := docsTransformsSetMdBytes()
 	if err != nil {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "docs/transforms/map.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	info := bindataFileInfo{name: "docs/transforms/set.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _docsTransformsMeanMd = []byte(`### Input
+var _docsTransformsSettimeMd = []byte(`The `+"`"+`settime`+"`"+` transform replaces each datapoint's timestamp with the value of its argument, which can be a unix timestamp in seconds, or an RFC3339 string. The data itself is not changed. Datapoints where the argument is `+"`"+`null`+"`"+`, such as when the field is missing, are returned unchanged.
+
+This is useful when the time at which something happened is stored in the data rather than in the timestamp. Given the following data:
 
 `+"`"+``+"`"+``+"`"+`json
-[1, 2, 6]
+[
+  { "t": 1, "data": { "measured": 1577836800, "value": 5 } },
+  { "t": 2, "data": { "measured": "2020-01-01T00:01:00Z", "value": 6 } }
+]
 `+"`"+``+"`"+``+"`"+`
 
-### Output
+`+"`"+`settime(d("measured"))`+"`"+` returns:
 
 `+"`"+``+"`"+``+"`"+`json
-[3]
+[
+  { "t": 1577836800, "data": { "measured": 1577836800, "value": 5 } },
+  { "t": 1577836860, "data": { "measured": "2020-01-01T00:01:00Z", "value": 6 } }
+]
 `+"`"+``+"`"+``+"`"+`
+
+Timestamps in other formats can be read with `+"`"+`parsetime`+"`"+`, such as `+"`"+`settime(d("date"):parsetime("%d/%m/%Y", "UTC"))`+"`"+`. Similarly, `+"`"+`setduration`+"`"+` sets the datapoint's duration in seconds.
+
+Note that PipeScript does not reorder datapoints, so if the new timestamps are not in increasing order, the output will not be ordered either.
 `)
 
-func docsTransformsMeanMdBytes() ([]byte, error) {
-	return _docsTransformsMeanMd, nil
+func docsTransformsSettimeMdBytes() ([]byte, error) {
+	return _docsTransformsSettimeMd, nil
 }
 
-func docsTransformsMeanMd() (*asset, error) {
-	bytes, err := docsTransformsMeanMdBytes()
+func docsTransformsSettimeMd() (*asset, error) {
+	bytes, err := docsTransformsSettimeMdBytes()
 	if err != nil {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "docs/transforms/mean.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	info := bindataFileInfo{name: "docs/transforms/settime.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _docsTransformsReduceMd = []byte(``+"`"+`reduce`+"`"+` performs a given transform on all the elements of a multi-element datapoint.
+var _docsTransformsSimilarityMd = []byte(`The `+"`"+`similarity`+"`"+` transform returns how similar two strings are, from 0 for completely different strings to 1 for equal strings. It is the [Levenshtein distance](https://en.wikipedia.org/wiki/Levenshtein_distance) between the strings divided by the length of the longer one, subtracted from 1, which makes it comparable between strings of different lengths.
 
-Suppose you have the following data:
+Given the following data:
 
 `+"`"+``+"`"+``+"`"+`json
 [
-  {
-    "a": 3,
-    "b": 5
-  },
-  {
-    "q": 10,
-    "z": 23
-  }
+  { "t": 1, "d": "kitten" },
+  { "t": 2, "d": "mitten" },
+  { "t": 3, "d": "sitting" }
 ]
 `+"`"+``+"`"+``+"`"+`
 
-Then the transform `+"`"+`reduce(sum)`+"`"+` will give you:
+`+"`"+`similarity('kitten')`+"`"+` returns:
 
 `+"`"+``+"`"+``+"`"+`json
-[8, 33]
+[
+  { "t": 1, "d": 1 },
+  { "t": 2, "d": 0.8333333333333334 },
+  { "t": 3, "d": 0.5714285714285714 }
+]
 `+"`"+``+"`"+``+"`"+`
 
-Reduce took each element in the given datapoint, and applied the transform `+"`"+`sum`+"`"+` to it.
-
-## Usage
+To find strings that nearly match, use `+"`"+`where(similarity('kitten') > 0.8)`+"`"+`. The string that is compared is the datapoint's data by default, and can be given as the second argument to compare two fields of an object, such as `+"`"+`similarity(d("typed"), d("expected"))`+"`"+`. If either string is null, it returns `+"`"+`null`+"`"+`.
+`)
 
-The reduce transform is particularly useful in conjunction with the `+"`"+`map`+"`"+` transform.
+func docsTransformsSimilarityMdBytes() ([]byte, error) {
+	return _docsTransformsSimilarityMd, nil
+}
 
-Suppose you want to find the average number of steps taken every weekday.
+func docsTransformsSimilarityMd() (*asset, error) {
+	bytes, err := docsTransformsSimilarityMdBytes()
+	if err != nil {
+		return nil, err
+	}
 
-Running the following transform will give you a map of weekday to average step count:
+	info := bindataFileInfo{name: "docs/transforms/similarity.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
 
-`+"`"+``+"`"+``+"`"+`
-while(day==d[1]:day,sum) | map(weekday, mean)
-`+"`"+``+"`"+``+"`"+`
+var _docsTransformsSpeedMd = []byte(`The `+"`"+`speed`+"`"+` transform returns the speed in meters per second at which each location was reached from the location of the previous datapoint. Like `+"`"+`distance`+"`"+`, it expects datapoints with `+"`"+`latitude`+"`"+` and `+"`"+`longitude`+"`"+` fields in decimal coordinates, and uses the [Haversine formula](https://en.wikipedia.org/wiki/Haversine_formula).
 
-For example, a possible result of the above transform could be:
+Given the following locations:
 
 `+"`"+``+"`"+``+"`"+`json
 [
-  {
-    "Monday": 12243,
-    "Tuesday": 13452,
-    "Wednesday": 14523,
-    "Thursday": 9543,
-    "Friday": 20487,
-    "Saturday": 3000,
-    "Sunday": 4000
-  }
+  { "t": 0, "d": { "latitude": 40.4243, "longitude": -86.9114 } },
+  { "t": 60, "d": { "latitude": 40.4263, "longitude": -86.9114 } },
+  { "t": 120, "d": { "latitude": 40.4263, "longitude": -86.9084 } }
 ]
 `+"`"+``+"`"+``+"`"+`
 
-You can now find the average per weekday by running `+"`"+`reduce(mean)`+"`"+`, giving a final transform:
+`+"`"+`speed`+"`"+` returns:
 
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 0, "d": null },
+  { "t": 60, "d": 3.706497554808963 },
+  { "t": 120, "d": 4.232305335732483 }
+]
 `+"`"+``+"`"+``+"`"+`
-while(day==d[1]:day,sum) | map(weekday, mean) | reduce(mean)
-`+"`"+``+"`"+``+"`"+`
+
+The first datapoint has no previous location, so its speed is `+"`"+`null`+"`"+`. It is also `+"`"+`null`+"`"+` if two datapoints have the same timestamp. Null values are skipped, and return `+"`"+`null`+"`"+`. To get kilometers per hour, use `+"`"+`speed*3.6`+"`"+`.
 `)
 
-func docsTransformsReduceMdBytes() ([]byte, error) {
-	return _docsTransformsReduceMd, nil
+func docsTransformsSpeedMdBytes() ([]byte, error) {
+	return _docsTransformsSpeedMd, nil
 }
 
-func docsTransformsReduceMd() (*asset, error) {
-	bytes, err := docsTransformsReduceMdBytes()
+func docsTransformsSpeedMd() (*asset, error) {
+	bytes, err := docsTransformsSpeedMdBytes()
 	if err != nil {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "docs/transforms/reduce.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	info := bindataFileInfo{name: "docs/transforms/speed.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _docsTransformsRegexMd = []byte(`The regex transform checks if the data string matches the given regex.
+var _docsTransformsStaypointsMd = []byte(`The `+"`"+`staypoints`+"`"+` transform finds the places where the user stayed, such as their home or workplace, from a stream of locations. It expects datapoints with `+"`"+`latitude`+"`"+` and `+"`"+`longitude`+"`"+` fields in decimal coordinates, and returns one datapoint for each stay, with the time of arrival as its timestamp, the length of the stay as its duration, and the average location of the stay as its data.
 
-For example, given a regex to check for valid usernames: `+"`"+`regex('^[a-z0-9_-]{3,16}$')`+"`"+`, we get:
+A stay is a run of locations that are all within `+"`"+`radius`+"`"+` meters of the first one, lasting at least `+"`"+`minduration`+"`"+` seconds. By default, the radius is 200 meters, and the minimum duration is 20 minutes.
+
+Given the following locations:
 
 `+"`"+``+"`"+``+"`"+`json
 [
-  "Hello World!",
-  "valid_username",
-  "1"
+  { "t": 0, "d": { "latitude": 40.4243, "longitude": -86.9114 } },
+  { "t": 600, "d": { "latitude": 40.4245, "longitude": -86.9114 } },
+  { "t": 1200, "d": { "latitude": 40.4243, "longitude": -86.9112 } },
+  { "t": 1800, "d": { "latitude": 40.4243, "longitude": -86.9114 } },
+  { "t": 2100, "d": { "latitude": 40.43, "longitude": -86.9114 } },
+  { "t": 2400, "d": { "latitude": 40.435, "longitude": -86.9114 } },
+  { "t": 2700, "d": { "latitude": 40.44, "longitude": -86.9114 } },
+  { "t": 3300, "d": { "latitude": 40.4401, "longitude": -86.9114 } },
+  { "t": 3900, "d": { "latitude": 40.44, "longitude": -86.9113 } }
 ]
 `+"`"+``+"`"+``+"`"+`
 
+`+"`"+`staypoints(200, 10m)`+"`"+` finds a stay at home and at work:
+
 `+"`"+``+"`"+``+"`"+`json
-[false,true,false]
+[
+  {
+    "t": 0,
+    "dt": 1800,
+    "d": { "latitude": 40.42435000003231, "longitude": -86.91134999996284 }
+  },
+  {
+    "t": 2700,
+    "dt": 1200,
+    "d": { "latitude": 40.440033333342896, "longitude": -86.91136666665014 }
+  }
+]
 `+"`"+``+"`"+``+"`"+`
+
+With `+"`"+`staypoints(200, 30m)`+"`"+`, the user was not at work for long enough, so only the first stay is returned. The stays can be grouped into places with `+"`"+`geohash`+"`"+`. Null values are skipped, and the movement between stays is returned by `+"`"+`trips`+"`"+`.
 `)
 
-func docsTransformsRegexMdBytes() ([]byte, error) {
-	return _docsTransformsRegexMd, nil
+func docsTransformsStaypointsMdBytes() ([]byte, error) {
+	return _docsTransformsStaypointsMd, nil
 }
 
-func docsTransformsRegexMd() (*asset, error) {
-	bytes, err := docsTransformsRegexMdBytes()
+func docsTransformsStaypointsMd() (*asset, error) {
+	bytes, err := docsTransformsStaypointsMdBytes()
 	if err != nil {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "docs/transforms/regex.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	info := bindataFileInfo{name: "docs/transforms/staypoints.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _docsTransformsStrftimeMd = []byte(`The `+"`"+`strftime`+"`"+` transform formats the datapoint's timestamp as a string, using the same directives as the C `+"`"+`strftime`+"`"+` function:
+
+| Directive | Meaning                          | Example    |
+| --------- | -------------------------------- | ---------- |
+| `+"`"+`%Y`+"`"+`      | Year                             | `+"`"+`2020`+"`"+`     |
+| `+"`"+`%y`+"`"+`      | Year without century             | `+"`"+`20`+"`"+`       |
+| `+"`"+`%m`+"`"+`      | Month                            | `+"`"+`01`+"`"+`       |
+| `+"`"+`%b`+"`"+`/`+"`"+`%h`+"`"+` | Abbreviated month name           | `+"`"+`Jan`+"`"+`      |
+| `+"`"+`%B`+"`"+`      | Month name                       | `+"`"+`January`+"`"+`  |
+| `+"`"+`%d`+"`"+`      | Day of the month                 | `+"`"+`05`+"`"+`       |
+| `+"`"+`%e`+"`"+`      | Day of the month, space-padded   | `+"`"+` 5`+"`"+`       |
+| `+"`"+`%j`+"`"+`      | Day of the year                  | `+"`"+`005`+"`"+`      |
+| `+"`"+`%a`+"`"+`      | Abbreviated weekday name         | `+"`"+`Sun`+"`"+`      |
+| `+"`"+`%A`+"`"+`      | Weekday name                     | `+"`"+`Sunday`+"`"+`   |
+| `+"`"+`%H`+"`"+`      | Hour (24-hour clock)             | `+"`"+`14`+"`"+`       |
+| `+"`"+`%I`+"`"+`      | Hour (12-hour clock)             | `+"`"+`02`+"`"+`       |
+| `+"`"+`%p`+"`"+`      | AM or PM                         | `+"`"+`PM`+"`"+`       |
+| `+"`"+`%M`+"`"+`      | Minute                           | `+"`"+`30`+"`"+`       |
+| `+"`"+`%S`+"`"+`      | Second                           | `+"`"+`00`+"`"+`       |
+| `+"`"+`%z`+"`"+`      | Time zone offset                 | `+"`"+`-0500`+"`"+`    |
+| `+"`"+`%Z`+"`"+`      | Time zone abbreviation           | `+"`"+`EST`+"`"+`      |
+| `+"`"+`%F`+"`"+`      | Equivalent to `+"`"+`%Y-%m-%d`+"`"+`         |            |
+| `+"`"+`%T`+"`"+`      | Equivalent to `+"`"+`%H:%M:%S`+"`"+`         |            |
+| `+"`"+`%%`+"`"+`      | A literal `+"`"+`%`+"`"+`                    |            |
+
+The second argument gives the time zone to use, which is the server's local time zone by default. For example, `+"`"+`strftime("%Y-%m-%d %H:%M", "UTC")`+"`"+` on a datapoint with timestamp `+"`"+`1577881800`+"`"+` returns `+"`"+`"2020-01-01 12:30"`+"`"+`.
+
+The `+"`"+`parsetime`+"`"+` transform does the reverse, parsing strings in the given format into unix timestamps. Times whose format does not include a time zone are interpreted in the given time zone. Numbers don't need to be zero-padded, so `+"`"+`parsetime("%d/%m/%Y %H:%M")`+"`"+` reads both `+"`"+`"01/02/2024 03:04"`+"`"+` and `+"`"+`"1/2/2024 3:04"`+"`"+`.
+`)
+
+func docsTransformsStrftimeMdBytes() ([]byte, error) {
+	return _docsTransformsStrftimeMd, nil
+}
+
+func docsTransformsStrftimeMd() (*asset, error) {
+	bytes, err := docsTransformsStrftimeMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/strftime.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -895,6 +2573,151 @@ func docsTransformsTMd() (*asset, error) {
 	return a, nil
 }
 
+var _docsTransformsTokensMd = []byte(`The `+"`"+`tokens`+"`"+` transform splits text into an array of words. Words are runs of letters and numbers in any language, so punctuation and whitespace separate words, and apostrophes within words are kept.
+
+Given the following data:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 1, "d": "Don't panic! The café's coffee is GREAT." },
+  { "t": 2, "d": "Größe 42" }
+]
+`+"`"+``+"`"+``+"`"+`
+
+`+"`"+`tokens`+"`"+` returns:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 1, "d": ["don't", "panic", "the", "café's", "coffee", "is", "great"] },
+  { "t": 2, "d": ["größe", "42"] }
+]
+`+"`"+``+"`"+``+"`"+`
+
+By default, the words are converted to lowercase, which can be turned off with `+"`"+`tokens(lowercase=false)`+"`"+`. Common English words such as "the" and "is", which carry little meaning on their own, can be removed with `+"`"+`tokens(stopwords=true)`+"`"+`:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 1, "d": ["panic", "café's", "coffee", "great"] },
+  { "t": 2, "d": ["größe", "42"] }
+]
+`+"`"+``+"`"+``+"`"+`
+
+Null values return `+"`"+`null`+"`"+`. The words can be counted over all datapoints with `+"`"+`wordfreq`+"`"+`, or grouped into phrases with `+"`"+`ngrams`+"`"+`.
+`)
+
+func docsTransformsTokensMdBytes() ([]byte, error) {
+	return _docsTransformsTokensMd, nil
+}
+
+func docsTransformsTokensMd() (*asset, error) {
+	bytes, err := docsTransformsTokensMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/tokens.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _docsTransformsTrendMd = []byte(`The `+"`"+`trend`+"`"+` transform finds the line that best fits the data over time, using least squares with the timestamp as `+"`"+`x`+"`"+`. It returns the `+"`"+`slope`+"`"+` of the line, which is the change of the value per second, the `+"`"+`intercept`+"`"+`, which is the value of the line at timestamp 0, and `+"`"+`r2`+"`"+`, the fraction of the variation of the data that is explained by the line.
+
+Given the following datapoints:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 0, "d": { "steps": 0, "hr": 62 } },
+  { "t": 60, "d": { "steps": 40, "hr": 75 } },
+  { "t": 120, "d": { "steps": 100, "hr": 90 } },
+  { "t": 180, "d": { "steps": 80, "hr": 88 } },
+  { "t": 240, "d": { "steps": 10, "hr": 70 } }
+]
+`+"`"+``+"`"+``+"`"+`
+
+`+"`"+`trend(d("hr"))`+"`"+` returns:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  {
+    "t": 0,
+    "dt": 240,
+    "d": {
+      "intercept": 71.2,
+      "r2": 0.1480633802816901,
+      "slope": 0.04833333333333332
+    }
+  }
+]
+`+"`"+``+"`"+``+"`"+`
+
+Without an argument, `+"`"+`trend`+"`"+` fits the data of the datapoints. To get the change per day, multiply the slope, as in `+"`"+`trend:d("slope")*1d`+"`"+`. The low `+"`"+`r2`+"`"+` above shows that the heart rate does not change steadily over time. Like `+"`"+`linreg`+"`"+`, null values are skipped, and `+"`"+`trend`+"`"+` returns `+"`"+`null`+"`"+` if there are fewer than two datapoints with different timestamps.
+`)
+
+func docsTransformsTrendMdBytes() ([]byte, error) {
+	return _docsTransformsTrendMd, nil
+}
+
+func docsTransformsTrendMd() (*asset, error) {
+	bytes, err := docsTransformsTrendMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/trend.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _docsTransformsTripsMd = []byte(`The `+"`"+`trips`+"`"+` transform finds the movement between the places where the user stayed, from a stream of locations. It expects datapoints with `+"`"+`latitude`+"`"+` and `+"`"+`longitude`+"`"+` fields in decimal coordinates, and returns one datapoint for each trip, with the time of departure as its timestamp, and the time until arrival as its duration. The data of each trip is an object with its `+"`"+`distance`+"`"+` in meters, and its average `+"`"+`speed`+"`"+` in meters per second.
+
+The stays are found in the same way as `+"`"+`staypoints`+"`"+`, with the same `+"`"+`radius`+"`"+` and `+"`"+`minduration`+"`"+` arguments, and the trips go from the last location of each stay, to the first location of the next stay. The movement before the first stay and after the last one is not returned, since it is not known where that trip started or ended.
+
+Given the following locations:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 0, "d": { "latitude": 40.4243, "longitude": -86.9114 } },
+  { "t": 600, "d": { "latitude": 40.4245, "longitude": -86.9114 } },
+  { "t": 1200, "d": { "latitude": 40.4243, "longitude": -86.9112 } },
+  { "t": 1800, "d": { "latitude": 40.4243, "longitude": -86.9114 } },
+  { "t": 2100, "d": { "latitude": 40.43, "longitude": -86.9114 } },
+  { "t": 2400, "d": { "latitude": 40.435, "longitude": -86.9114 } },
+  { "t": 2700, "d": { "latitude": 40.44, "longitude": -86.9114 } },
+  { "t": 3300, "d": { "latitude": 40.4401, "longitude": -86.9114 } },
+  { "t": 3900, "d": { "latitude": 40.44, "longitude": -86.9113 } }
+]
+`+"`"+``+"`"+``+"`"+`
+
+`+"`"+`trips(200, 10m)`+"`"+` returns the trip from home to work:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  {
+    "t": 1800,
+    "dt": 900,
+    "d": { "distance": 1745.7603483188411, "speed": 1.939733720354268 }
+  }
+]
+`+"`"+``+"`"+``+"`"+`
+
+With `+"`"+`trips(200, 30m)`+"`"+`, the user was not at work for long enough for it to be a stay, so the movement from home never arrives at a stay, and no trips are returned. Null values are skipped. The distance is measured between the recorded locations, so infrequent locations give a shorter distance than was actually traveled.
+`)
+
+func docsTransformsTripsMdBytes() ([]byte, error) {
+	return _docsTransformsTripsMd, nil
+}
+
+func docsTransformsTripsMd() (*asset, error) {
+	bytes, err := docsTransformsTripsMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/trips.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _docsTransformsTshiftMd = []byte(`This transform is not particularly useful for PipeScript by itself, but becomes very frequently used in dataset and merge queries.
 
 Every datapoint has a data portion, as well as a timestamp, which is hidden from computations in PipeScript by default. `+"`"+`tshift`+"`"+` shifts the timestamps of a stream by the given amount in seconds. This allows making it seem like the data of a stream came before/after its actual timestamps. This is useful in datasets, since a tshift can allow interpolating between different time ranges - it allows asking questions such as "does exercise today impact my mood a week later?". The datapoints corresponding to mood can be tshifted back by a week to correspond directly to the original datapoints where your exercise data is shown.
@@ -1046,6 +2869,97 @@ func docsTransformsWhileMd() (*asset, error) {
 	return a, nil
 }
 
+var _docsTransformsWordfreqMd = []byte(`The `+"`"+`wordfreq`+"`"+` transform returns an object with the number of times that each word appears in the text of all of the datapoints. It accepts either strings, which are split into lowercase words like in `+"`"+`tokens`+"`"+`, or arrays of words.
+
+Given the following data:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 1, "d": "The coffee is great" },
+  { "t": 2, "d": "the coffee, the coffee" }
+]
+`+"`"+``+"`"+``+"`"+`
+
+`+"`"+`wordfreq`+"`"+` returns:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  {
+    "t": 1,
+    "dt": 1,
+    "d": { "coffee": 3, "great": 1, "is": 1, "the": 3 }
+  }
+]
+`+"`"+``+"`"+``+"`"+`
+
+To leave out common words, split the text with `+"`"+`tokens`+"`"+` first, such as `+"`"+`tokens(stopwords=true):wordfreq`+"`"+`, which returns `+"`"+`{"coffee": 3, "great": 1}`+"`"+`. To count phrases instead of words, use `+"`"+`ngrams(2):wordfreq`+"`"+`. Null values are skipped.
+`)
+
+func docsTransformsWordfreqMdBytes() ([]byte, error) {
+	return _docsTransformsWordfreqMd, nil
+}
+
+func docsTransformsWordfreqMd() (*asset, error) {
+	bytes, err := docsTransformsWordfreqMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/wordfreq.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _docsTransformsZscoreMd = []byte(`The `+"`"+`zscore`+"`"+` transform flags unusual values by comparing each value to the values before it. It returns the number of standard deviations between the value and the mean of the previous values in its window, which is negative for values below the mean.
+
+Given the following heart rate datapoints:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 0, "d": 70 },
+  { "t": 60, "d": 72 },
+  { "t": 120, "d": 71 },
+  { "t": 180, "d": 73 },
+  { "t": 240, "d": 120 },
+  { "t": 300, "d": 72 }
+]
+`+"`"+``+"`"+``+"`"+`
+
+`+"`"+`zscore(1h)`+"`"+` uses the datapoints from the previous hour, and returns:
+
+`+"`"+``+"`"+``+"`"+`json
+[
+  { "t": 0, "d": null },
+  { "t": 60, "d": null },
+  { "t": 120, "d": 0 },
+  { "t": 180, "d": 2 },
+  { "t": 240, "d": 37.56793845821194 },
+  { "t": 300, "d": -0.42359896394546465 }
+]
+`+"`"+``+"`"+``+"`"+`
+
+The score is `+"`"+`null`+"`"+` until there are at least two previous values, and also when all previous values are the same and the current value is different, since its score would be infinite. The unusual datapoints can then be found with `+"`"+`where(zscore(1h) > 3)`+"`"+`.
+
+The window is a number of seconds, or a number of datapoints with `+"`"+`by="count"`+"`"+`, so `+"`"+`zscore(100, by="count")`+"`"+` uses the 100 previous datapoints. A window of 0, which is the default, uses all previous datapoints. If a `+"`"+`threshold`+"`"+` is given, `+"`"+`zscore`+"`"+` returns `+"`"+`true`+"`"+` for values whose score is further than the threshold from 0, and `+"`"+`false`+"`"+` otherwise, including when the score is `+"`"+`null`+"`"+`. This gives the same datapoints as `+"`"+`where(zscore(1h) > 3 or zscore(1h) < -3)`+"`"+`.
+
+Null values are skipped, and return `+"`"+`null`+"`"+`, or `+"`"+`false`+"`"+` with a threshold. The related `+"`"+`mad_zscore`+"`"+` and `+"`"+`iqr_outlier`+"`"+` transforms are less affected by earlier outliers, and `+"`"+`cusum`+"`"+` detects small shifts in the mean.
+`)
+
+func docsTransformsZscoreMdBytes() ([]byte, error) {
+	return _docsTransformsZscoreMd, nil
+}
+
+func docsTransformsZscoreMd() (*asset, error) {
+	bytes, err := docsTransformsZscoreMdBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "docs/transforms/zscore.md", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -1098,28 +3012,73 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
+	"docs/transforms/afilter.md": docsTransformsAfilterMd,
 	"docs/transforms/alltrue.md": docsTransformsAlltrueMd,
+	"docs/transforms/amap.md": docsTransformsAmapMd,
 	"docs/transforms/anytrue.md": docsTransformsAnytrueMd,
+	"docs/transforms/asof.md": docsTransformsAsofMd,
+	"docs/transforms/bbox.md": docsTransformsBboxMd,
+	"docs/transforms/bearing.md": docsTransformsBearingMd,
 	"docs/transforms/bucket.md": docsTransformsBucketMd,
+	"docs/transforms/centroid.md": docsTransformsCentroidMd,
 	"docs/transforms/changed.md": docsTransformsChangedMd,
+	"docs/transforms/coalesce.md": docsTransformsCoalesceMd,
 	"docs/transforms/contains.md": docsTransformsContainsMd,
+	"docs/transforms/corr.md": docsTransformsCorrMd,
 	"docs/transforms/count.md": docsTransformsCountMd,
+	"docs/transforms/cov.md": docsTransformsCovMd,
+	"docs/transforms/cusum.md": docsTransformsCusumMd,
 	"docs/transforms/d.md": docsTransformsDMd,
+	"docs/transforms/dedup.md": docsTransformsDedupMd,
+	"docs/transforms/default.md": docsTransformsDefaultMd,
 	"docs/transforms/distance.md": docsTransformsDistanceMd,
 	"docs/transforms/dt.md": docsTransformsDtMd,
 	"docs/transforms/first.md": docsTransformsFirstMd,
+	"docs/transforms/geohash.md": docsTransformsGeohashMd,
+	"docs/transforms/groupby.md": docsTransformsGroupbyMd,
 	"docs/transforms/i.md": docsTransformsIMd,
+	"docs/transforms/incircle.md": docsTransformsIncircleMd,
+	"docs/transforms/ingeofence.md": docsTransformsIngeofenceMd,
+	"docs/transforms/int.md": docsTransformsIntMd,
+	"docs/transforms/iqr_outlier.md": docsTransformsIqr_outlierMd,
+	"docs/transforms/isnull.md": docsTransformsIsnullMd,
+	"docs/transforms/json_parse.md": docsTransformsJson_parseMd,
 	"docs/transforms/last.md": docsTransformsLastMd,
+	"docs/transforms/levenshtein.md": docsTransformsLevenshteinMd,
+	"docs/transforms/linreg.md": docsTransformsLinregMd,
+	"docs/transforms/mad_zscore.md": docsTransformsMad_zscoreMd,
 	"docs/transforms/map.md": docsTransformsMapMd,
 	"docs/transforms/mean.md": docsTransformsMeanMd,
+	"docs/transforms/ngrams.md": docsTransformsNgramsMd,
+	"docs/transforms/partition.md": docsTransformsPartitionMd,
+	"docs/transforms/pathlength.md": docsTransformsPathlengthMd,
+	"docs/transforms/pattern.md": docsTransformsPatternMd,
+	"docs/transforms/pick.md": docsTransformsPickMd,
 	"docs/transforms/reduce.md": docsTransformsReduceMd,
 	"docs/transforms/regex.md": docsTransformsRegexMd,
+	"docs/transforms/regex_extract.md": docsTransformsRegex_extractMd,
+	"docs/transforms/regex_findall.md": docsTransformsRegex_findallMd,
+	"docs/transforms/regex_parse.md": docsTransformsRegex_parseMd,
+	"docs/transforms/regex_replace.md": docsTransformsRegex_replaceMd,
+	"docs/transforms/reorder.md": docsTransformsReorderMd,
+	"docs/transforms/round.md": docsTransformsRoundMd,
+	"docs/transforms/set.md": docsTransformsSetMd,
+	"docs/transforms/settime.md": docsTransformsSettimeMd,
+	"docs/transforms/similarity.md": docsTransformsSimilarityMd,
+	"docs/transforms/speed.md": docsTransformsSpeedMd,
+	"docs/transforms/staypoints.md": docsTransformsStaypointsMd,
+	"docs/transforms/strftime.md": docsTransformsStrftimeMd,
 	"docs/transforms/sum.md": docsTransformsSumMd,
 	"docs/transforms/t.md": docsTransformsTMd,
+	"docs/transforms/tokens.md": docsTransformsTokensMd,
+	"docs/transforms/trend.md": docsTransformsTrendMd,
+	"docs/transforms/trips.md": docsTransformsTripsMd,
 	"docs/transforms/tshift.md": docsTransformsTshiftMd,
 	"docs/transforms/wc.md": docsTransformsWcMd,
 	"docs/transforms/where.md": docsTransformsWhereMd,
 	"docs/transforms/while.md": docsTransformsWhileMd,
+	"docs/transforms/wordfreq.md": docsTransformsWordfreqMd,
+	"docs/transforms/zscore.md": docsTransformsZscoreMd,
 }
 
 // AssetDir returns the file names below a certain
@@ -1164,28 +3123,73 @@ type bintree struct {
 var _bintree = &bintree{nil, map[string]*bintree{
 	"docs": &bintree{nil, map[string]*bintree{
 		"transforms": &bintree{nil, map[string]*bintree{
+			"afilter.md": &bintree{docsTransformsAfilterMd, map[string]*bintree{}},
 			"alltrue.md": &bintree{docsTransformsAlltrueMd, map[string]*bintree{}},
+			"amap.md": &bintree{docsTransformsAmapMd, map[string]*bintree{}},
 			"anytrue.md": &bintree{docsTransformsAnytrueMd, map[string]*bintree{}},
+			"asof.md": &bintree{docsTransformsAsofMd, map[string]*bintree{}},
+			"bbox.md": &bintree{docsTransformsBboxMd, map[string]*bintree{}},
+			"bearing.md": &bintree{docsTransformsBearingMd, map[string]*bintree{}},
 			"bucket.md": &bintree{docsTransformsBucketMd, map[string]*bintree{}},
+			"centroid.md": &bintree{docsTransformsCentroidMd, map[string]*bintree{}},
 			"changed.md": &bintree{docsTransformsChangedMd, map[string]*bintree{}},
+			"coalesce.md": &bintree{docsTransformsCoalesceMd, map[string]*bintree{}},
 			"contains.md": &bintree{docsTransformsContainsMd, map[string]*bintree{}},
+			"corr.md": &bintree{docsTransformsCorrMd, map[string]*bintree{}},
 			"count.md": &bintree{docsTransformsCountMd, map[string]*bintree{}},
+			"cov.md": &bintree{docsTransformsCovMd, map[string]*bintree{}},
+			"cusum.md": &bintree{docsTransformsCusumMd, map[string]*bintree{}},
 			"d.md": &bintree{docsTransformsDMd, map[string]*bintree{}},
+			"dedup.md": &bintree{docsTransformsDedupMd, map[string]*bintree{}},
+			"default.md": &bintree{docsTransformsDefaultMd, map[string]*bintree{}},
 			"distance.md": &bintree{docsTransformsDistanceMd, map[string]*bintree{}},
 			"dt.md": &bintree{docsTransformsDtMd, map[string]*bintree{}},
 			"first.md": &bintree{docsTransformsFirstMd, map[string]*bintree{}},
+			"geohash.md": &bintree{docsTransformsGeohashMd, map[string]*bintree{}},
+			"groupby.md": &bintree{docsTransformsGroupbyMd, map[string]*bintree{}},
 			"i.md": &bintree{docsTransformsIMd, map[string]*bintree{}},
+			"incircle.md": &bintree{docsTransformsIncircleMd, map[string]*bintree{}},
+			"ingeofence.md": &bintree{docsTransformsIngeofenceMd, map[string]*bintree{}},
+			"int.md": &bintree{docsTransformsIntMd, map[string]*bintree{}},
+			"iqr_outlier.md": &bintree{docsTransformsIqr_outlierMd, map[string]*bintree{}},
+			"isnull.md": &bintree{docsTransformsIsnullMd, map[string]*bintree{}},
+			"json_parse.md": &bintree{docsTransformsJson_parseMd, map[string]*bintree{}},
 			"last.md": &bintree{docsTransformsLastMd, map[string]*bintree{}},
+			"levenshtein.md": &bintree{docsTransformsLevenshteinMd, map[string]*bintree{}},
+			"linreg.md": &bintree{docsTransformsLinregMd, map[string]*bintree{}},
+			"mad_zscore.md": &bintree{docsTransformsMad_zscoreMd, map[string]*bintree{}},
 			"map.md": &bintree{docsTransformsMapMd, map[string]*bintree{}},
 			"mean.md": &bintree{docsTransformsMeanMd, map[string]*bintree{}},
+			"ngrams.md": &bintree{docsTransformsNgramsMd, map[string]*bintree{}},
+			"partition.md": &bintree{docsTransformsPartitionMd, map[string]*bintree{}},
+			"pathlength.md": &bintree{docsTransformsPathlengthMd, map[string]*bintree{}},
+			"pattern.md": &bintree{docsTransformsPatternMd, map[string]*bintree{}},
+			"pick.md": &bintree{docsTransformsPickMd, map[string]*bintree{}},
 			"reduce.md": &bintree{docsTransformsReduceMd, map[string]*bintree{}},
 			"regex.md": &bintree{docsTransformsRegexMd, map[string]*bintree{}},
+			"regex_extract.md": &bintree{docsTransformsRegex_extractMd, map[string]*bintree{}},
+			"regex_findall.md": &bintree{docsTransformsRegex_findallMd, map[string]*bintree{}},
+			"regex_parse.md": &bintree{docsTransformsRegex_parseMd, map[string]*bintree{}},
+			"regex_replace.md": &bintree{docsTransformsRegex_replaceMd, map[string]*bintree{}},
+			"reorder.md": &bintree{docsTransformsReorderMd, map[string]*bintree{}},
+			"round.md": &bintree{docsTransformsRoundMd, map[string]*bintree{}},
+			"set.md": &bintree{docsTransformsSetMd, map[string]*bintree{}},
+			"settime.md": &bintree{docsTransformsSettimeMd, map[string]*bintree{}},
+			"similarity.md": &bintree{docsTransformsSimilarityMd, map[string]*bintree{}},
+			"speed.md": &bintree{docsTransformsSpeedMd, map[string]*bintree{}},
+			"staypoints.md": &bintree{docsTransformsStaypointsMd, map[string]*bintree{}},
+			"strftime.md": &bintree{docsTransformsStrftimeMd, map[string]*bintree{}},
 			"sum.md": &bintree{docsTransformsSumMd, map[string]*bintree{}},
 			"t.md": &bintree{docsTransformsTMd, map[string]*bintree{}},
+			"tokens.md": &bintree{docsTransformsTokensMd, map[string]*bintree{}},
+			"trend.md": &bintree{docsTransformsTrendMd, map[string]*bintree{}},
+			"trips.md": &bintree{docsTransformsTripsMd, map[string]*bintree{}},
 			"tshift.md": &bintree{docsTransformsTshiftMd, map[string]*bintree{}},
 			"wc.md": &bintree{docsTransformsWcMd, map[string]*bintree{}},
 			"where.md": &bintree{docsTransformsWhereMd, map[string]*bintree{}},
 			"while.md": &bintree{docsTransformsWhileMd, map[string]*bintree{}},
+			"wordfreq.md": &bintree{docsTransformsWordfreqMd, map[string]*bintree{}},
+			"zscore.md": &bintree{docsTransformsZscoreMd, map[string]*bintree{}},
 		}},
 	}},
 }}
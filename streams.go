@@ -0,0 +1,115 @@
+package pipescript
+
+import (
+	"fmt"
+	"sort"
+)
+
+type streamIterator struct {
+	Name string
+
+	it *BufferIterator
+}
+
+func (s *streamIterator) Next(e *TransformEnv, out *Datapoint) (*Datapoint, error) {
+	if s.it == nil {
+		return nil, fmt.Errorf("The input stream '$%s' was not given", s.Name)
+	}
+	dp, err := s.it.Next()
+	if err != nil || dp == nil {
+		return nil, err
+	}
+	out.Timestamp = dp.Timestamp
+	out.Duration = dp.Duration
+	out.Data = dp.Data
+	return out, nil
+}
+
+func (s *streamIterator) OneToOne() bool {
+	return false
+}
+
+// NewStreamTransform returns a transform that ignores its input, and instead returns the datapoints
+// of the named input stream given with Pipe.InputStreams. In PipeScript, it is written as $name.
+func NewStreamTransform(name string) *Transform {
+	return &Transform{
+		Name:        "$" + name,
+		Description: "Named input stream",
+		Constructor: func(transform *Transform, consts []interface{}, pipes []*Pipe) (TransformIterator, error) {
+			return &streamIterator{Name: name}, nil
+		},
+	}
+}
+
+// subPipes returns all pipes that are run by the element, which can refer to named streams
+func (pe *PipeElement) subPipes() []*Pipe {
+	sp := append(append([]*Pipe{}, pe.Args...), pe.PipeArgs...)
+	switch v := pe.Iter.(type) {
+	case *oneToOneObjectTransform:
+		for _, p := range v.pipes {
+			sp = append(sp, p)
+		}
+	case *aggregateObjectTransform:
+		for _, p := range v.pipes {
+			sp = append(sp, p)
+		}
+	}
+	return sp
+}
+
+func (pe *PipeElement) inputStreams(streams map[string]*Buffer) {
+	pe.Env.Streams = streams
+	if si, ok := pe.Iter.(*streamIterator); ok {
+		si.it = nil
+		if b, ok := streams[si.Name]; ok {
+			si.it = b.Iterator()
+		}
+	}
+	for _, p := range pe.subPipes() {
+		p.inputStreams(streams)
+	}
+}
+
+func (p *Pipe) inputStreams(streams map[string]*Buffer) {
+	for i := range p.Arr {
+		p.Arr[i].inputStreams(streams)
+	}
+}
+
+// InputStreams sets the named input streams that the pipe refers to as $name. All references to
+// a stream read it from the start, so copies of the stream are buffered while they are needed.
+func (p *Pipe) InputStreams(streams map[string]Iterator) {
+	bufs := make(map[string]*Buffer, len(streams))
+	for k, it := range streams {
+		bufs[k] = NewBuffer(it)
+	}
+	p.inputStreams(bufs)
+}
+
+func (pe *PipeElement) streams(names map[string]bool) {
+	if si, ok := pe.Iter.(*streamIterator); ok {
+		names[si.Name] = true
+	}
+	for _, p := range pe.subPipes() {
+		p.streams(names)
+	}
+}
+
+func (p *Pipe) streams(names map[string]bool) {
+	for i := range p.Arr {
+		p.Arr[i].streams(names)
+	}
+}
+
+// Streams returns the sorted names of the input streams that the pipe refers to, which need to be
+// given with InputStreams before the pipe is run.
+func (p *Pipe) Streams() []string {
+	names := make(map[string]bool)
+	p.streams(names)
+	res := make([]string, 0, len(names))
+	for k := range names {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
+}
@@ -0,0 +1,44 @@
+package pipescript
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreams(t *testing.T) {
+	hr := []Datapoint{
+		{Timestamp: 1, Data: 70},
+		{Timestamp: 2, Data: 80},
+	}
+	TestCase{
+		Pipescript: "$heartrate",
+		Parsed:     "$heartrate",
+		Input: []Datapoint{
+			{Timestamp: 5, Data: 1},
+		},
+		Streams: map[string][]Datapoint{"heartrate": hr},
+		Output:  hr,
+	}.Run(t)
+	TestCase{
+		Pipescript: "$heartrate:(d + 1)",
+		Parsed:     "$heartrate:(d + 1)",
+		Streams:    map[string][]Datapoint{"heartrate": hr},
+		Output: []Datapoint{
+			{Timestamp: 1, Data: float64(71)},
+			{Timestamp: 2, Data: float64(81)},
+		},
+	}.Run(t)
+	TestCase{
+		Pipescript:  "$heartrate",
+		Streams:     map[string][]Datapoint{"steps": hr},
+		OutputError: true,
+	}.Run(t)
+	TestCase{
+		Pipescript: "$heartrate(1)",
+		Parsed:     "error",
+	}.Run(t)
+
+	require.Equal(t, []string{"a", "b"}, MustParse("$b:d + $a:($b:d)").Streams())
+	require.Equal(t, []string{}, MustParse("d + 1").Streams())
+}
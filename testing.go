@@ -12,6 +12,8 @@ type TestCase struct {
 	Parsed      string      // The output of the parsed pipe.String(). "" if there is to be an error
 	OutputError bool        // Whether there is to be an error during output
 	Output      []Datapoint // The output Datapoints
+
+	Streams map[string][]Datapoint // The named input streams, if the script uses any
 }
 
 func (tc TestCase) Run(t *testing.T) {
@@ -33,6 +35,17 @@ func (tc TestCase) Run(t *testing.T) {
 	p.InputIterator(dpi)
 	p2.InputIterator(dpi2)
 
+	if tc.Streams != nil {
+		s := make(map[string]Iterator)
+		s2 := make(map[string]Iterator)
+		for k, v := range tc.Streams {
+			s[k] = NewDatapointArrayIterator(v)
+			s2[k] = NewDatapointArrayIterator(v)
+		}
+		p.InputStreams(s)
+		p2.InputStreams(s2)
+	}
+
 	for i := range tc.Output {
 		v, err := p.Next(&Datapoint{})
 		require.NoError(t, err, "Script '%s' (%s) gave error on output %d", tc.Pipescript, p.String(), i)
@@ -2,8 +2,12 @@ package pipescript
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"sync"
+
+	"github.com/xeipuuv/gojsonschema"
 )
 
 type Iterator interface {
@@ -61,11 +65,96 @@ func (a *ArgType) UnmarshalJSON(b []byte) error {
 }
 
 type TransformArg struct {
+	Name        string                 `json:"name,omitempty"`    // The name used to give the arg as a keyword, such as bucket(start=5) (optional)
 	Description string                 `json:"description"`       // A description of what the arg represents
 	Schema      map[string]interface{} `json:"schema"`            // The schema that the arg conforms to
 	Optional    bool                   `json:"optional"`          // Whether the arg is optional
 	Default     *Pipe                  `json:"default,omitempty"` // If the arg is optional, what is its default value
 	Type        ArgType                `json:"arg_type"`          // The type expected of the arg
+	Variadic    bool                   `json:"variadic"`          // Whether the arg can be repeated. Only the last arg can be variadic.
+
+	validator *gojsonschema.Schema // The compiled Schema, set up during registration or on first use
+}
+
+// defaultPipe returns a copy of the arg's default, since a pipe can only be the arg of one transform.
+// Optional args of transforms that were not registered might not have a default set, so they fall back
+// to the schema's default, or null.
+func (a *TransformArg) defaultPipe() *Pipe {
+	if a.Default != nil {
+		return a.Default.Copy()
+	}
+	return MustPipe(NewConstTransform(a.Schema["default"]), nil)
+}
+
+// schemaLock protects the compiled schemas of args, which are compiled the first time that they are used
+// for transforms that were not registered
+var schemaLock sync.Mutex
+
+// compileSchema returns the compiled schema of the arg, or nil if the schema accepts everything.
+// The compiled schema is kept in the arg, so that it is only compiled once.
+func (a *TransformArg) compileSchema() (*gojsonschema.Schema, error) {
+	schemaLock.Lock()
+	defer schemaLock.Unlock()
+	if a.validator != nil || len(a.Schema) == 0 {
+		return a.validator, nil
+	}
+	v, err := gojsonschema.NewSchema(gojsonschema.NewGoLoader(a.Schema))
+	if err != nil {
+		return nil, err
+	}
+	a.validator = v
+	return v, nil
+}
+
+// label returns the name of the arg for use in errors, or its description if it has no name
+func (a *TransformArg) label() string {
+	if a.Name != "" {
+		return a.Name
+	}
+	return a.Description
+}
+
+// Validate checks a constant value of the arg against its Schema, and returns the value converted to
+// the type given in the schema: whole numbers become int64 for "integer" args, and all numbers become
+// float64 for "number" args, including nullable ones, so that constructors can use the value directly.
+func (a *TransformArg) Validate(v interface{}) (interface{}, error) {
+	s, err := a.compileSchema()
+	if err != nil || s == nil {
+		return v, err
+	}
+	res, err := s.Validate(gojsonschema.NewGoLoader(v))
+	if err != nil {
+		return nil, err
+	}
+	if !res.Valid() {
+		return nil, errors.New(res.Errors()[0].Description())
+	}
+	switch numericType(a.Schema["type"]) {
+	case "integer":
+		if f, ok := FloatNoBool(v); ok && f == math.Trunc(f) {
+			if f < -1<<63 || f >= 1<<63 {
+				return nil, fmt.Errorf("%v is too large to be an integer", v)
+			}
+			return int64(f), nil
+		}
+	case "number":
+		if f, ok := FloatNoBool(v); ok {
+			return f, nil
+		}
+	}
+	return v, nil
+}
+
+// numericType returns the type of numbers in a schema type, which can also be an array of types, such as ["number", "null"]
+func numericType(t interface{}) interface{} {
+	if types, ok := t.([]interface{}); ok {
+		for _, ti := range types {
+			if ti == "number" || ti == "integer" {
+				return ti
+			}
+		}
+	}
+	return t
 }
 
 type Transform struct {
@@ -116,15 +205,33 @@ func (t *Transform) Register() error {
 		t.OutputSchema = make(map[string]interface{})
 	}
 	hadOptional := false
+	names := make(map[string]bool)
 	for i := range t.Args {
 		if t.Args[i].Optional {
 			hadOptional = true
-		} else if hadOptional {
-			return fmt.Errorf("Transform '%s' has required arg after optional args", t.Name)
+		} else if hadOptional && t.Args[i].Name == "" {
+			// Required args after optional ones can only be given by name
+			return fmt.Errorf("Transform '%s' has unnamed required arg after optional args", t.Name)
+		}
+		if t.Args[i].Name != "" {
+			if names[t.Args[i].Name] {
+				return fmt.Errorf("Transform '%s' has multiple args named '%s'", t.Name, t.Args[i].Name)
+			}
+			names[t.Args[i].Name] = true
+		}
+		if t.Args[i].Variadic && i != len(t.Args)-1 {
+			return fmt.Errorf("Transform '%s' has a variadic arg that is not its last arg", t.Name)
 		}
 		if t.Args[i].Schema == nil {
 			t.Args[i].Schema = make(map[string]interface{})
 		}
+		if _, err := t.Args[i].compileSchema(); err != nil {
+			return fmt.Errorf("Transform '%s' arg %d (%s) has an invalid schema: %w", t.Name, i, t.Args[i].label(), err)
+		}
+		// Optional args can get their default value from the schema
+		if dv, ok := t.Args[i].Schema["default"]; ok && t.Args[i].Optional && t.Args[i].Default == nil {
+			t.Args[i].Default = MustPipe(NewConstTransform(dv), nil)
+		}
 	}
 
 	RegistryLock.Lock()
@@ -134,3 +241,66 @@ func (t *Transform) Register() error {
 
 	return nil
 }
+
+// IsVariadic returns true if the transform's last arg can be given any number of times
+func (t *Transform) IsVariadic() bool {
+	return len(t.Args) > 0 && t.Args[len(t.Args)-1].Variadic
+}
+
+// Arg returns the TransformArg that describes the ith arg given to the transform. All args
+// past the end of a variadic transform's Args are described by its last arg.
+func (t *Transform) Arg(i int) *TransformArg {
+	if i >= len(t.Args) && t.IsVariadic() {
+		return &t.Args[len(t.Args)-1]
+	}
+	return &t.Args[i]
+}
+
+// KeywordArgs combines positional args with args given by name (as in bucket(start=5)), returning
+// the full list of positional args. Optional args that are skipped are set to their default values.
+func (t *Transform) KeywordArgs(args []*Pipe, kwargs map[string]*Pipe) ([]*Pipe, error) {
+	if len(kwargs) == 0 {
+		return args, nil
+	}
+	if len(args) > len(t.Args) && !t.IsVariadic() {
+		return nil, fmt.Errorf("Transform '%s' takes %d arguments, but %d given", t.Name, len(t.Args), len(args))
+	}
+	res := make([]*Pipe, len(t.Args))
+	if len(args) > len(res) {
+		res = make([]*Pipe, len(args))
+	}
+	copy(res, args)
+	last := len(args)
+	for name, v := range kwargs {
+		found := false
+		for i := range t.Args {
+			if t.Args[i].Name == name {
+				if res[i] != nil {
+					return nil, fmt.Errorf("Transform '%s' arg '%s' was given multiple times", t.Name, name)
+				}
+				res[i] = v
+				if i >= last {
+					last = i + 1
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("Transform '%s' has no arg named '%s'", t.Name, name)
+		}
+	}
+	// Fill in the args that were skipped
+	for i := 0; i < last; i++ {
+		if res[i] == nil {
+			if !t.Args[i].Optional {
+				if t.Args[i].Name != "" {
+					return nil, fmt.Errorf("Transform '%s' requires arg '%s'", t.Name, t.Args[i].Name)
+				}
+				return nil, fmt.Errorf("Transform '%s' requires arg %d (%s)", t.Name, i, t.Args[i].label())
+			}
+			res[i] = t.Args[i].defaultPipe()
+		}
+	}
+	return res[:last], nil
+}
@@ -1,9 +1,5 @@
 package pipescript
 
-import (
-	"encoding/json"
-)
-
 type AggregatorFunc func(e *TransformEnv, consts []interface{}, pipes []*Pipe, out *Datapoint) (*Datapoint, error)
 type Aggregator struct {
 	ConstArgs []interface{}
@@ -48,6 +44,7 @@ type Basic struct {
 
 func (s *Basic) Next(e *TransformEnv, out *Datapoint) (*Datapoint, error) {
 	dp, arr, err := e.Next(s.Args)
+	s.Args = arr // Keep the slice, which is resized for variadic args
 	if err != nil || dp == nil {
 		return nil, err
 	}
@@ -100,6 +97,7 @@ type ArgBasic struct {
 
 func (s *ArgBasic) Next(e *TransformEnv, out *Datapoint) (*Datapoint, error) {
 	dp, arr, err := e.Next(s.Args)
+	s.Args = arr // Keep the slice, which is resized for variadic args
 	if err != nil || dp == nil {
 		return nil, err
 	}
@@ -156,9 +154,8 @@ func (c *ConstIterator) OneToOne() bool {
 }
 
 func NewConstTransform(v interface{}) *Transform {
-	b, _ := json.Marshal(v)
 	return &Transform{
-		Name:        string(b),
+		Name:        constString(v),
 		Description: "Constant value",
 		Constructor: func(transform *Transform, consts []interface{}, pipes []*Pipe) (TransformIterator, error) {
 			return &ConstIterator{Value: v}, nil
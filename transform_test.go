@@ -0,0 +1,324 @@
+package pipescript
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// kwTransform returns an array of its three args, where the middle one is optional,
+// and the last is required, so it can only be given by name
+var kwTransform = &Transform{
+	Name:        "kwtest",
+	Description: "Returns its args as an array",
+	Args: []TransformArg{
+		{
+			Name:        "a",
+			Description: "The first arg",
+			Type:        TransformArgType,
+		},
+		{
+			Name:        "b",
+			Description: "The second arg",
+			Type:        ConstArgType,
+			Optional:    true,
+			Default:     MustPipe(NewConstTransform("default"), nil),
+		},
+		{
+			Name:        "c",
+			Description: "The third arg",
+			Type:        TransformArgType,
+		},
+	},
+	Constructor: NewArgBasic(func(args []*Datapoint, consts []interface{}, pipes []*Pipe, out *Datapoint) (*Datapoint, error) {
+		out.Data = []interface{}{args[0].Data, consts[0], args[1].Data}
+		return out, nil
+	}),
+}
+
+// varTransform returns an array of its args, which are a constant followed by any number of transforms
+var varTransform = &Transform{
+	Name:        "vartest",
+	Description: "Returns its args as an array",
+	Args: []TransformArg{
+		{
+			Description: "The first arg",
+			Type:        ConstArgType,
+		},
+		{
+			Name:        "rest",
+			Description: "The remaining args",
+			Type:        TransformArgType,
+			Optional:    true,
+			Variadic:    true,
+		},
+	},
+	Constructor: NewArgBasic(func(args []*Datapoint, consts []interface{}, pipes []*Pipe, out *Datapoint) (*Datapoint, error) {
+		res := []interface{}{consts[0]}
+		for _, a := range args {
+			res = append(res, a.Data)
+		}
+		out.Data = res
+		return out, nil
+	}),
+}
+
+func TestRegister(t *testing.T) {
+	require.NoError(t, kwTransform.Register())
+
+	require.Error(t, (&Transform{
+		Name:        "badtest",
+		Constructor: kwTransform.Constructor,
+		Args: []TransformArg{
+			{Description: "optional", Optional: true, Default: IdentityPipe},
+			{Description: "required but unnamed"},
+		},
+	}).Register())
+
+	require.Error(t, (&Transform{
+		Name:        "badtest",
+		Constructor: kwTransform.Constructor,
+		Args: []TransformArg{
+			{Name: "a", Description: "first"},
+			{Name: "a", Description: "duplicate"},
+		},
+	}).Register())
+
+	require.Error(t, (&Transform{
+		Name:        "badtest",
+		Constructor: kwTransform.Constructor,
+		Args: []TransformArg{
+			{Description: "variadic", Variadic: true},
+			{Description: "last"},
+		},
+	}).Register())
+}
+
+func TestKeywordArgs(t *testing.T) {
+	require.NoError(t, kwTransform.Register())
+	defer Unregister("kwtest")
+
+	for script, result := range map[string][]interface{}{
+		"kwtest(1, c=3)":            {1.0, "default", 3.0},
+		"kwtest(c=3, a=1)":          {1.0, "default", 3.0},
+		"kwtest(1, 'x', c = 3)":     {1.0, "x", 3.0},
+		"kwtest(a=1, b='y', c=3+1)": {1.0, "y", 4.0},
+		// Args can also be given by name in brackets
+		"kwtest[c=3, a=1]":    {1.0, "default", 3.0},
+		"kwtest[1, c=3]":      {1.0, "default", 3.0},
+		"kwtest[1, 'x', c=2]": {1.0, "x", 2.0},
+	} {
+		p, err := Parse(script)
+		require.NoError(t, err, script)
+		v, err := p.GetConst()
+		require.NoError(t, err, script)
+		require.Equal(t, result, v, script)
+	}
+
+	for _, script := range []string{
+		"kwtest(1, 'x')",        // c is required
+		"kwtest(1, d=3)",        // unknown name
+		"kwtest(1, a=3, c=2)",   // a given twice
+		"kwtest(a=1, a=2, c=3)", // duplicate names
+		"kwtest(a=1, 2, c=3)",   // positional after named
+		"kwtest[a=1, 2, c=3]",
+		"kwtest(a=1, b=d, c=2)", // b must be constant
+	} {
+		_, err := Parse(script)
+		require.Error(t, err, script)
+	}
+}
+
+func TestVariadicArgs(t *testing.T) {
+	require.NoError(t, varTransform.Register())
+	defer Unregister("vartest")
+
+	for script, result := range map[string][]interface{}{
+		"vartest('x')":              {"x"},
+		"vartest('x', 1)":           {"x", 1.0},
+		"vartest('x', 1, 2, 3)":     {"x", 1.0, 2.0, 3.0},
+		"vartest('x', rest=1)":      {"x", 1.0},
+		"vartest('x', 1, 2+3, 'y')": {"x", 1.0, 5.0, "y"},
+	} {
+		p, err := Parse(script)
+		require.NoError(t, err, script)
+		v, err := p.GetConst()
+		require.NoError(t, err, script)
+		require.Equal(t, result, v, script)
+	}
+
+	// The args are all given to the transform when it runs on a stream
+	TestCase{
+		Pipescript: "vartest('x', d, d + 1, d - 1)",
+		Parsed:     "vartest(\"x\",d,(d + 1),(d - 1))",
+		Input: []Datapoint{
+			{Timestamp: 1, Data: 1.0},
+			{Timestamp: 2, Data: 2.0},
+		},
+		Output: []Datapoint{
+			{Timestamp: 1, Data: []interface{}{"x", 1.0, 2.0, 0.0}},
+			{Timestamp: 2, Data: []interface{}{"x", 2.0, 3.0, 1.0}},
+		},
+	}.Run(t)
+
+	_, err := Parse("vartest('x', 1, rest=2)")
+	require.Error(t, err)
+
+	// Transforms that aren't variadic still reject extra args
+	require.NoError(t, kwTransform.Register())
+	defer Unregister("kwtest")
+	_, err = Parse("kwtest(1, 'x', 2, 3)")
+	require.Error(t, err)
+}
+
+// schemaTransform returns its const args, which are validated against their schemas
+var schemaTransform = &Transform{
+	Name:        "schematest",
+	Description: "Returns its args as an array",
+	Args: []TransformArg{
+		{
+			Description: "A non-negative integer",
+			Type:        ConstArgType,
+			Schema: map[string]interface{}{
+				"type":    "integer",
+				"minimum": 0,
+			},
+		},
+		{
+			Description: "A number with a default",
+			Type:        ConstArgType,
+			Optional:    true,
+			Schema: map[string]interface{}{
+				"type":    "number",
+				"default": 2,
+			},
+		},
+	},
+	Constructor: NewArgBasic(func(args []*Datapoint, consts []interface{}, pipes []*Pipe, out *Datapoint) (*Datapoint, error) {
+		out.Data = consts
+		return out, nil
+	}),
+}
+
+func TestConstArgSchema(t *testing.T) {
+	require.NoError(t, schemaTransform.Register())
+	defer Unregister("schematest")
+
+	// Values are converted to the type given in the schema, and defaults come from the schema
+	for script, result := range map[string][]interface{}{
+		"schematest(3)":      {int64(3), float64(2)},
+		"schematest(3, 1.5)": {int64(3), 1.5},
+		"schematest(1+1, 4)": {int64(2), float64(4)},
+	} {
+		p, err := Parse(script)
+		require.NoError(t, err, script)
+		v, err := p.GetConst()
+		require.NoError(t, err, script)
+		require.Equal(t, result, v, script)
+	}
+
+	for _, script := range []string{
+		"schematest(1.5)",
+		"schematest(-1)",
+		"schematest('hi')",
+		"schematest(1, 'hi')",
+		// Integers must fit in an int64
+		"schematest(1e20)",
+		"schematest(9223372036854775807)",
+	} {
+		_, err := Parse(script)
+		require.Error(t, err, script)
+	}
+
+	_, err := Parse("schematest(1, 'hi')")
+	require.Contains(t, err.Error(), "Transform 'schematest' arg 1 (A number with a default)")
+
+	// Args with a name are shown by their name
+	require.NoError(t, (&Transform{
+		Name:        "namedtest",
+		Constructor: schemaTransform.Constructor,
+		Args: []TransformArg{
+			{
+				Name:        "num",
+				Description: "A number",
+				Type:        ConstArgType,
+				Schema:      map[string]interface{}{"type": "number"},
+			},
+		},
+	}).Register())
+	defer Unregister("namedtest")
+	_, err = Parse("namedtest('hi')")
+	require.Contains(t, err.Error(), "Transform 'namedtest' arg 0 (num)")
+
+	require.Error(t, (&Transform{
+		Name:        "badtest",
+		Constructor: schemaTransform.Constructor,
+		Args: []TransformArg{
+			{Description: "bad schema", Schema: map[string]interface{}{"type": 5}},
+		},
+	}).Register())
+
+	// Transforms that were not registered compile their schemas once, when they are first used
+	unregistered := &Transform{
+		Name:        "unregistered",
+		Constructor: schemaTransform.Constructor,
+		Args: []TransformArg{
+			{Description: "A string", Type: ConstArgType, Schema: map[string]interface{}{"type": "string"}},
+		},
+	}
+	_, err = NewPipeElement(unregistered, []*Pipe{MustPipe(NewConstTransform("hi"), nil)})
+	require.NoError(t, err)
+	v := unregistered.Args[0].validator
+	require.NotNil(t, v)
+	_, err = NewPipeElement(unregistered, []*Pipe{MustPipe(NewConstTransform(1), nil)})
+	require.Error(t, err)
+	require.True(t, v == unregistered.Args[0].validator)
+}
+
+func TestDefaultArgs(t *testing.T) {
+	defTransform := &Transform{
+		Name:        "deftest",
+		Description: "Returns its arg",
+		Args: []TransformArg{
+			{
+				Description: "The value to return",
+				Type:        TransformArgType,
+				Optional:    true,
+				Default:     IdentityPipe,
+			},
+		},
+		Constructor: NewArgBasic(func(args []*Datapoint, consts []interface{}, pipes []*Pipe, out *Datapoint) (*Datapoint, error) {
+			out.Data = args[0].Data
+			return out, nil
+		}),
+	}
+	require.NoError(t, defTransform.Register())
+	defer Unregister("deftest")
+
+	// Each use of the transform gets its own copy of the default pipe
+	TestCase{
+		Pipescript: "deftest + deftest",
+		Input: []Datapoint{
+			{Timestamp: 1, Data: 1},
+			{Timestamp: 2, Data: 2},
+		},
+		Output: []Datapoint{
+			{Timestamp: 1, Data: float64(2)},
+			{Timestamp: 2, Data: float64(4)},
+		},
+	}.Run(t)
+
+	// A transform that was not registered has no default set, so the arg is null
+	pe, err := NewPipeElement(&Transform{
+		Name:        "unregistered",
+		Constructor: defTransform.Constructor,
+		Args: []TransformArg{
+			{Description: "optional", Type: TransformArgType, Optional: true},
+		},
+	}, nil)
+	require.NoError(t, err)
+	pe.Input(NewBuffer(NewDatapointArrayIterator([]Datapoint{{Timestamp: 1, Data: 1}})))
+	dp, err := pe.Next(&Datapoint{})
+	require.NoError(t, err)
+	require.Nil(t, dp.Data)
+}
@@ -0,0 +1,198 @@
+package arrays
+
+import (
+	"errors"
+
+	"github.com/heedy/pipescript"
+)
+
+// arrayIndex converts a possibly negative index into an index from the start of an array
+// of the given length. Negative indices count from the end of the array.
+func arrayIndex(i int64, length int) int64 {
+	if i < 0 {
+		return i + int64(length)
+	}
+	return i
+}
+
+var Aunique = &pipescript.Transform{
+	Name:        "aunique",
+	Description: "Removes duplicate elements from an array, keeping the first occurrence of each",
+	InputSchema: map[string]interface{}{
+		"type": "array",
+	},
+	OutputSchema: map[string]interface{}{
+		"type": "array",
+	},
+	Constructor: pipescript.NewBasic(nil, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		if dp.Data == nil {
+			out.Data = nil
+			return out, nil
+		}
+		arr, ok := dp.Data.([]interface{})
+		if !ok {
+			return nil, errNotArray
+		}
+		res := make([]interface{}, 0, len(arr))
+	outer:
+		for _, v := range arr {
+			for _, r := range res {
+				if pipescript.Equal(v, r) {
+					continue outer
+				}
+			}
+			res = append(res, v)
+		}
+		out.Data = res
+		return out, nil
+	}),
+}
+
+func flatten(arr []interface{}, depth int64, res []interface{}) []interface{} {
+	for _, v := range arr {
+		va, ok := v.([]interface{})
+		if ok && depth > 0 {
+			res = flatten(va, depth-1, res)
+		} else {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+var Aflatten = &pipescript.Transform{
+	Name:        "aflatten",
+	Description: "Flattens nested arrays into a single array",
+	InputSchema: map[string]interface{}{
+		"type": "array",
+	},
+	OutputSchema: map[string]interface{}{
+		"type": "array",
+	},
+	Args: []pipescript.TransformArg{
+		{
+			Name:        "depth",
+			Description: "The number of levels of nesting to flatten",
+			Type:        pipescript.ConstArgType,
+			Optional:    true,
+			Default:     pipescript.MustPipe(pipescript.NewConstTransform(1), nil),
+			Schema: map[string]interface{}{
+				"type":    "integer",
+				"minimum": 0,
+			},
+		},
+	},
+	Constructor: pipescript.NewBasic(nil, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		if dp.Data == nil {
+			out.Data = nil
+			return out, nil
+		}
+		arr, ok := dp.Data.([]interface{})
+		if !ok {
+			return nil, errNotArray
+		}
+		out.Data = flatten(arr, consts[0].(int64), make([]interface{}, 0, len(arr)))
+		return out, nil
+	}),
+}
+
+var Aslice = &pipescript.Transform{
+	Name:        "aslice",
+	Description: "Returns the elements of an array from the start index up to (but not including) the end index. Negative indices count from the end of the array.",
+	InputSchema: map[string]interface{}{
+		"type": "array",
+	},
+	OutputSchema: map[string]interface{}{
+		"type": "array",
+	},
+	Args: []pipescript.TransformArg{
+		{
+			Name:        "start",
+			Description: "The index of the first element to include",
+			Type:        pipescript.ConstArgType,
+			Schema: map[string]interface{}{
+				"type": "integer",
+			},
+		},
+		{
+			Name:        "end",
+			Description: "The index at which to stop. null goes to the end of the array.",
+			Type:        pipescript.ConstArgType,
+			Optional:    true,
+			Default:     pipescript.MustPipe(pipescript.NewConstTransform(nil), nil),
+			Schema: map[string]interface{}{
+				"type": []string{"integer", "null"},
+			},
+		},
+	},
+	Constructor: pipescript.NewBasic(func(consts []interface{}, pipes []*pipescript.Pipe) ([]interface{}, []*pipescript.Pipe, error) {
+		if consts[1] == nil {
+			return consts, pipes, nil
+		}
+		end, ok := pipescript.IntNoBool(consts[1])
+		if !ok {
+			return nil, nil, errors.New("aslice end must be an integer or null")
+		}
+		return []interface{}{consts[0], end}, pipes, nil
+	}, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		if dp.Data == nil {
+			out.Data = nil
+			return out, nil
+		}
+		arr, ok := dp.Data.([]interface{})
+		if !ok {
+			return nil, errNotArray
+		}
+		start := arrayIndex(consts[0].(int64), len(arr))
+		end := int64(len(arr))
+		if consts[1] != nil {
+			end = arrayIndex(consts[1].(int64), len(arr))
+		}
+		if start < 0 {
+			start = 0
+		}
+		if end > int64(len(arr)) {
+			end = int64(len(arr))
+		}
+		if start >= end {
+			out.Data = []interface{}{}
+			return out, nil
+		}
+		res := make([]interface{}, end-start)
+		copy(res, arr[start:end])
+		out.Data = res
+		return out, nil
+	}),
+}
+
+var Aindex = &pipescript.Transform{
+	Name:        "aindex",
+	Description: "Returns the element of an array at the given index, or null if the index is out of range. Negative indices count from the end of the array.",
+	InputSchema: map[string]interface{}{
+		"type": "array",
+	},
+	Args: []pipescript.TransformArg{
+		{
+			Description: "The index of the element",
+			Type:        pipescript.ConstArgType,
+			Schema: map[string]interface{}{
+				"type": "integer",
+			},
+		},
+	},
+	Constructor: pipescript.NewBasic(nil, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		out.Data = nil
+		if dp.Data == nil {
+			return out, nil
+		}
+		arr, ok := dp.Data.([]interface{})
+		if !ok {
+			return nil, errNotArray
+		}
+		i := arrayIndex(consts[0].(int64), len(arr))
+		if i >= 0 && i < int64(len(arr)) {
+			out.Data = arr[i]
+		}
+		return out, nil
+	}),
+}
@@ -0,0 +1,98 @@
+package arrays
+
+import (
+	"testing"
+
+	"github.com/heedy/pipescript"
+)
+
+func TestAunique(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "aunique",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: []interface{}{1, "a", 1.0, "a", 2}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: []interface{}{1, "a", 2}},
+		},
+	}.Run(t)
+}
+
+func TestAflatten(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "aflatten(-1)",
+		Parsed:     "error",
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "aflatten",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: []interface{}{1, []interface{}{2, []interface{}{3}}}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: []interface{}{1, 2, []interface{}{3}}},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "aflatten(5)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: []interface{}{1, []interface{}{2, []interface{}{3}}}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: []interface{}{1, 2, 3}},
+		},
+	}.Run(t)
+}
+
+func TestAslice(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "aslice('a')",
+		Parsed:     "error",
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "aslice(1)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: []interface{}{1, 2, 3}},
+			{Timestamp: 2, Data: []interface{}{}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: []interface{}{2, 3}},
+			{Timestamp: 2, Data: []interface{}{}},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "aslice(-3,-1)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: []interface{}{1, 2, 3, 4}},
+			{Timestamp: 2, Data: []interface{}{1}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: []interface{}{2, 3}},
+			{Timestamp: 2, Data: []interface{}{}},
+		},
+	}.Run(t)
+}
+
+func TestAindex(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "aindex(-1)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: []interface{}{1, 2, 3}},
+			{Timestamp: 2, Data: []interface{}{}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 3},
+			{Timestamp: 2, Data: nil},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "aindex(0)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "hi"},
+		},
+		OutputError: true,
+	}.Run(t)
+}
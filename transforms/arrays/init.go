@@ -0,0 +1,18 @@
+/*
+Package arrays contains transforms that operate on array data, such as lists of nearby wifi networks.
+*/
+package arrays
+
+func Register() {
+	Amap.Register()
+	Afilter.Register()
+	Asort.Register()
+
+	Aunique.Register()
+	Aflatten.Register()
+	Aslice.Register()
+	Aindex.Register()
+
+	Asum.Register()
+	Amean.Register()
+}
@@ -0,0 +1,238 @@
+package arrays
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/heedy/pipescript"
+	"github.com/heedy/pipescript/resources"
+)
+
+var errNotArray = errors.New("Array transforms can only be used on arrays")
+
+// runPipe runs a copy of the given pipe over the elements of the array, and returns the data
+// of all datapoints that the pipe outputs
+func runPipe(p *pipescript.Pipe, dp *pipescript.Datapoint, vals []interface{}) ([]interface{}, error) {
+	p = p.Copy()
+	p.InputIterator(pipescript.NewValueArrayIterator(dp, vals))
+	res := make([]interface{}, 0, len(vals))
+	tmp := &pipescript.Datapoint{}
+	for {
+		dd, err := p.Next(tmp)
+		if err != nil || dd == nil {
+			return res, err
+		}
+		res = append(res, dd.Data)
+	}
+}
+
+// typeRank gives the order in which values of different types are sorted
+func typeRank(v interface{}) int {
+	switch v.(type) {
+	case nil:
+		return 0
+	case bool:
+		return 1
+	case string:
+		return 3
+	case []interface{}:
+		return 4
+	case map[string]interface{}:
+		return 5
+	}
+	return 2
+}
+
+// compare returns -1, 0 or 1 depending on whether a is less than, equal to, or greater than b.
+// Numbers are compared by value, strings lexically, and arrays element by element.
+// Values of different types are ordered null < boolean < number < string < array < object.
+func compare(a, b interface{}) int {
+	ra, rb := typeRank(a), typeRank(b)
+	if ra != rb {
+		if ra < rb {
+			return -1
+		}
+		return 1
+	}
+	switch va := a.(type) {
+	case bool:
+		vb := b.(bool)
+		if va == vb {
+			return 0
+		}
+		if !va {
+			return -1
+		}
+		return 1
+	case string:
+		return strings.Compare(va, b.(string))
+	case []interface{}:
+		vb := b.([]interface{})
+		for i := 0; i < len(va) && i < len(vb); i++ {
+			if c := compare(va[i], vb[i]); c != 0 {
+				return c
+			}
+		}
+		return compare(len(va), len(vb))
+	case map[string]interface{}, nil:
+		return 0
+	}
+	fa, _ := pipescript.FloatNoBool(a)
+	fb, _ := pipescript.FloatNoBool(b)
+	if fa < fb {
+		return -1
+	}
+	if fa > fb {
+		return 1
+	}
+	return 0
+}
+
+var Amap = &pipescript.Transform{
+	Name:          "amap",
+	Description:   "Runs the given transform over the elements of an array, and returns an array of its outputs",
+	Documentation: string(resources.MustAsset("docs/transforms/amap.md")),
+	InputSchema: map[string]interface{}{
+		"type": "array",
+	},
+	OutputSchema: map[string]interface{}{
+		"type": "array",
+	},
+	Args: []pipescript.TransformArg{
+		{
+			Description: "The transform to run over the array's elements",
+			Type:        pipescript.PipeArgType,
+		},
+	},
+	Constructor: pipescript.NewBasic(nil, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		if dp.Data == nil {
+			out.Data = nil
+			return out, nil
+		}
+		arr, ok := dp.Data.([]interface{})
+		if !ok {
+			return nil, errNotArray
+		}
+		res, err := runPipe(pipes[0], dp, arr)
+		out.Data = res
+		return out, err
+	}),
+}
+
+var Afilter = &pipescript.Transform{
+	Name:          "afilter",
+	Description:   "Returns an array of only the elements for which the given transform is true",
+	Documentation: string(resources.MustAsset("docs/transforms/afilter.md")),
+	InputSchema: map[string]interface{}{
+		"type": "array",
+	},
+	OutputSchema: map[string]interface{}{
+		"type": "array",
+	},
+	Args: []pipescript.TransformArg{
+		{
+			Description: "The statement to check for truth on each element",
+			Type:        pipescript.OneToOnePipeArgType,
+			Schema: map[string]interface{}{
+				"type": "boolean",
+			},
+		},
+	},
+	Constructor: pipescript.NewBasic(nil, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		if dp.Data == nil {
+			out.Data = nil
+			return out, nil
+		}
+		arr, ok := dp.Data.([]interface{})
+		if !ok {
+			return nil, errNotArray
+		}
+		keep, err := runPipe(pipes[0], dp, arr)
+		if err != nil {
+			return nil, err
+		}
+		res := make([]interface{}, 0, len(arr))
+		for i := range arr {
+			if keep[i] == nil {
+				continue
+			}
+			b, ok := pipescript.Bool(keep[i])
+			if !ok {
+				return nil, errors.New("afilter requires a boolean statement")
+			}
+			if b {
+				res = append(res, arr[i])
+			}
+		}
+		out.Data = res
+		return out, nil
+	}),
+}
+
+var Asort = &pipescript.Transform{
+	Name:        "asort",
+	Description: "Sorts the elements of an array, optionally by the value of the given transform on each element",
+	InputSchema: map[string]interface{}{
+		"type": "array",
+	},
+	OutputSchema: map[string]interface{}{
+		"type": "array",
+	},
+	Args: []pipescript.TransformArg{
+		{
+			Name:        "key",
+			Description: "The value by which to sort each element",
+			Type:        pipescript.OneToOnePipeArgType,
+			Optional:    true,
+			Default:     pipescript.IdentityPipe,
+		},
+		{
+			Name:        "descending",
+			Description: "Whether to sort in descending order",
+			Type:        pipescript.ConstArgType,
+			Optional:    true,
+			Default:     pipescript.MustPipe(pipescript.NewConstTransform(false), nil),
+			Schema: map[string]interface{}{
+				"type": "boolean",
+			},
+		},
+	},
+	Constructor: pipescript.NewBasic(func(consts []interface{}, pipes []*pipescript.Pipe) ([]interface{}, []*pipescript.Pipe, error) {
+		if _, ok := consts[0].(bool); !ok {
+			return nil, nil, errors.New("asort's descending argument must be a boolean")
+		}
+		return consts, pipes, nil
+	}, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		if dp.Data == nil {
+			out.Data = nil
+			return out, nil
+		}
+		arr, ok := dp.Data.([]interface{})
+		if !ok {
+			return nil, errNotArray
+		}
+		keys, err := runPipe(pipes[0], dp, arr)
+		if err != nil {
+			return nil, err
+		}
+		idx := make([]int, len(arr))
+		for i := range idx {
+			idx[i] = i
+		}
+		desc := consts[0].(bool)
+		sort.SliceStable(idx, func(i, j int) bool {
+			c := compare(keys[idx[i]], keys[idx[j]])
+			if desc {
+				return c > 0
+			}
+			return c < 0
+		})
+		res := make([]interface{}, len(arr))
+		for i := range idx {
+			res[i] = arr[idx[i]]
+		}
+		out.Data = res
+		return out, nil
+	}),
+}
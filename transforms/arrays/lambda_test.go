@@ -0,0 +1,105 @@
+package arrays
+
+import (
+	"testing"
+
+	"github.com/heedy/pipescript"
+	"github.com/heedy/pipescript/transforms/core"
+	"github.com/heedy/pipescript/transforms/numeric"
+)
+
+func TestAmap(t *testing.T) {
+	Register()
+	core.Register()
+	numeric.Register()
+	pipescript.TestCase{
+		Pipescript: "amap",
+		Parsed:     "error",
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "amap(d('ssid'))",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: []interface{}{
+				map[string]interface{}{"ssid": "home", "signal": -40},
+				map[string]interface{}{"ssid": "cafe", "signal": -80},
+			}},
+			{Timestamp: 2, Data: []interface{}{}},
+			{Timestamp: 3, Data: nil},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: []interface{}{"home", "cafe"}},
+			{Timestamp: 2, Data: []interface{}{}},
+			{Timestamp: 3, Data: nil},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "amap(where(d > 2))",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: []interface{}{1, 5, 2, 8}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: []interface{}{5, 8}},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "amap(sum)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: []interface{}{1, 5, 2, 8}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: []interface{}{float64(16)}},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "amap(d+1)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "hi"},
+		},
+		OutputError: true,
+	}.Run(t)
+}
+
+func TestAfilter(t *testing.T) {
+	Register()
+	core.Register()
+	pipescript.TestCase{
+		Pipescript: "afilter(sum)",
+		Parsed:     "error",
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "afilter(d > 2)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: []interface{}{1, 5, 2, 8}},
+			{Timestamp: 2, Data: []interface{}{3, nil}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: []interface{}{5, 8}},
+			{Timestamp: 2, Data: []interface{}{3}},
+		},
+	}.Run(t)
+}
+
+func TestAsort(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "asort",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: []interface{}{3, "b", 1.5, nil, "a", true}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: []interface{}{nil, true, 1.5, 3, "a", "b"}},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "asort(d('signal'), true) | aindex(0) | d('ssid')",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: []interface{}{
+				map[string]interface{}{"ssid": "cafe", "signal": -80},
+				map[string]interface{}{"ssid": "home", "signal": -40},
+			}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "home"},
+		},
+	}.Run(t)
+}
@@ -0,0 +1,82 @@
+package arrays
+
+import (
+	"errors"
+
+	"github.com/heedy/pipescript"
+)
+
+// arraySum returns the sum and number of non-null elements of a numeric array
+func arraySum(arr []interface{}) (float64, int, error) {
+	sum := float64(0)
+	count := 0
+	for _, v := range arr {
+		// Null elements are skipped, in the same way as the sum and mean transforms
+		if v == nil {
+			continue
+		}
+		f, ok := pipescript.Float(v)
+		if !ok {
+			return 0, 0, errors.New("Array elements must be numbers")
+		}
+		sum += f
+		count++
+	}
+	return sum, count, nil
+}
+
+var Asum = &pipescript.Transform{
+	Name:        "asum",
+	Description: "Returns the sum of the elements of a numeric array",
+	InputSchema: map[string]interface{}{
+		"type": "array",
+		"items": map[string]interface{}{
+			"type": "number",
+		},
+	},
+	OutputSchema: map[string]interface{}{
+		"type": "number",
+	},
+	Constructor: pipescript.NewBasic(nil, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		if dp.Data == nil {
+			out.Data = nil
+			return out, nil
+		}
+		arr, ok := dp.Data.([]interface{})
+		if !ok {
+			return nil, errNotArray
+		}
+		sum, _, err := arraySum(arr)
+		out.Data = sum
+		return out, err
+	}),
+}
+
+var Amean = &pipescript.Transform{
+	Name:        "amean",
+	Description: "Returns the mean of the elements of a numeric array",
+	InputSchema: map[string]interface{}{
+		"type": "array",
+		"items": map[string]interface{}{
+			"type": "number",
+		},
+	},
+	OutputSchema: map[string]interface{}{
+		"type": "number",
+	},
+	Constructor: pipescript.NewBasic(nil, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		out.Data = nil
+		if dp.Data == nil {
+			return out, nil
+		}
+		arr, ok := dp.Data.([]interface{})
+		if !ok {
+			return nil, errNotArray
+		}
+		sum, count, err := arraySum(arr)
+		if count > 0 {
+			out.Data = sum / float64(count)
+		}
+		return out, err
+	}),
+}
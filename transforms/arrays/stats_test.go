@@ -0,0 +1,44 @@
+package arrays
+
+import (
+	"testing"
+
+	"github.com/heedy/pipescript"
+)
+
+func TestAsum(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "asum",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: []interface{}{1, 2.5, nil}},
+			{Timestamp: 2, Data: []interface{}{}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 3.5},
+			{Timestamp: 2, Data: float64(0)},
+		},
+	}.Run(t)
+}
+
+func TestAmean(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "amean",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: []interface{}{1, 2, nil, 6}},
+			{Timestamp: 2, Data: []interface{}{}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: float64(3)},
+			{Timestamp: 2, Data: nil},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "amean",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: []interface{}{"a"}},
+		},
+		OutputError: true,
+	}.Run(t)
+}
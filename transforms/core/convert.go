@@ -0,0 +1,173 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/heedy/pipescript"
+	"github.com/heedy/pipescript/resources"
+)
+
+var strictArg = pipescript.TransformArg{
+	Name:        "strict",
+	Description: "If true, data that can't be converted gives an error. If false, it gives null.",
+	Type:        pipescript.ConstArgType,
+	Optional:    true,
+	Default:     pipescript.MustPipe(pipescript.NewConstTransform(true), nil),
+	Schema: map[string]interface{}{
+		"type": "boolean",
+	},
+}
+
+// newConversion creates a transform converting the datapoint's data with the given function, which returns
+// false if the data can't be converted. Null data is always converted to null.
+func newConversion(name, description, documentation string, outputSchema map[string]interface{}, convert func(v interface{}) (interface{}, bool)) *pipescript.Transform {
+	return &pipescript.Transform{
+		Name:          name,
+		Description:   description,
+		Documentation: documentation,
+		OutputSchema:  outputSchema,
+		Args: []pipescript.TransformArg{
+			strictArg,
+		},
+		Constructor: pipescript.NewBasic(func(consts []interface{}, pipes []*pipescript.Pipe) ([]interface{}, []*pipescript.Pipe, error) {
+			if _, ok := consts[0].(bool); !ok {
+				return nil, nil, fmt.Errorf("%s requires a boolean strict argument", name)
+			}
+			return consts, pipes, nil
+		}, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+			if dp.Data == nil {
+				out.Data = nil
+				return out, nil
+			}
+			v, ok := convert(dp.Data)
+			if !ok {
+				if consts[0].(bool) {
+					return nil, fmt.Errorf("%s: could not convert %s", name, pipescript.ToString(dp.Data))
+				}
+				v = nil
+			}
+			out.Data = v
+			return out, nil
+		}),
+	}
+}
+
+// parseFloat parses a number from a string, ignoring surrounding whitespace.
+// NaN and infinity are not accepted, since they can't be represented in JSON.
+func parseFloat(s string) (float64, bool) {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, false
+	}
+	return f, true
+}
+
+func toFloat(v interface{}) (interface{}, bool) {
+	if s, ok := v.(string); ok {
+		return parseFloat(s)
+	}
+	return pipescript.Float(v)
+}
+
+func toInt(v interface{}) (interface{}, bool) {
+	switch n := v.(type) {
+	case int64, int, bool:
+		return pipescript.Int(n)
+	}
+	f, ok := toFloat(v)
+	if !ok {
+		return nil, false
+	}
+	// Fractions are truncated towards zero
+	t := math.Trunc(f.(float64))
+	if t < math.MinInt64 || t >= math.MaxInt64 {
+		return nil, false
+	}
+	return int64(t), true
+}
+
+func toString(v interface{}) (interface{}, bool) {
+	return pipescript.ToString(v), true
+}
+
+func toBool(v interface{}) (interface{}, bool) {
+	if s, ok := v.(string); ok {
+		b, err := strconv.ParseBool(strings.TrimSpace(s))
+		return b, err == nil
+	}
+	if b, ok := v.(bool); ok {
+		return b, true
+	}
+	f, ok := pipescript.Float(v)
+	if !ok {
+		return nil, false
+	}
+	return f != 0, true
+}
+
+func toJSON(v interface{}) (interface{}, bool) {
+	b, err := json.Marshal(v)
+	return string(b), err == nil
+}
+
+func fromJSON(v interface{}) (interface{}, bool) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, false
+	}
+	var res interface{}
+	err := json.Unmarshal([]byte(s), &res)
+	return res, err == nil
+}
+
+var (
+	ToInt = newConversion("int", "Converts data to an integer, truncating any fractional part", string(resources.MustAsset("docs/transforms/int.md")), map[string]interface{}{
+		"type": "integer",
+	}, toInt)
+	ToFloat = newConversion("float", "Converts data to a number", "", map[string]interface{}{
+		"type": "number",
+	}, toFloat)
+	ToString = newConversion("string", "Converts data to a string", "", map[string]interface{}{
+		"type": "string",
+	}, toString)
+	ToBool = newConversion("bool", "Converts data to a boolean", "", map[string]interface{}{
+		"type": "boolean",
+	}, toBool)
+	JSONParse     = newConversion("json_parse", "Parses a string containing JSON", string(resources.MustAsset("docs/transforms/json_parse.md")), map[string]interface{}{}, fromJSON)
+	JSONStringify = newConversion("json_stringify", "Encodes data as a JSON string", "", map[string]interface{}{
+		"type": "string",
+	}, toJSON)
+)
+
+var Typeof = &pipescript.Transform{
+	Name:        "typeof",
+	Description: "Returns the JSON type of the datapoint's data: null, boolean, number, string, array or object",
+	OutputSchema: map[string]interface{}{
+		"type": "string",
+		"enum": []string{"null", "boolean", "number", "string", "array", "object"},
+	},
+	Constructor: pipescript.NewBasic(nil, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		switch dp.Data.(type) {
+		case nil:
+			out.Data = "null"
+		case bool:
+			out.Data = "boolean"
+		case string:
+			out.Data = "string"
+		case []interface{}:
+			out.Data = "array"
+		case map[string]interface{}:
+			out.Data = "object"
+		default:
+			if _, ok := pipescript.FloatNoBool(dp.Data); !ok {
+				return nil, fmt.Errorf("typeof: unrecognized data type %T", dp.Data)
+			}
+			out.Data = "number"
+		}
+		return out, nil
+	}),
+}
@@ -0,0 +1,192 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/heedy/pipescript"
+)
+
+func TestInt(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "int",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "12"},
+			{Timestamp: 2, Data: " 3.7 "},
+			{Timestamp: 3, Data: -2.5},
+			{Timestamp: 4, Data: true},
+			{Timestamp: 5, Data: nil},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: int64(12)},
+			{Timestamp: 2, Data: int64(3)},
+			{Timestamp: 3, Data: int64(-2)},
+			{Timestamp: 4, Data: int64(1)},
+			{Timestamp: 5, Data: nil},
+		},
+	}.Run(t)
+
+	pipescript.TestCase{
+		Pipescript: "int",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: ""},
+		},
+		OutputError: true,
+	}.Run(t)
+
+	pipescript.TestCase{
+		Pipescript: "int(false)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: ""},
+			{Timestamp: 2, Data: map[string]interface{}{"a": 1}},
+			{Timestamp: 3, Data: "1e30"},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: nil},
+			{Timestamp: 2, Data: nil},
+			{Timestamp: 3, Data: nil},
+		},
+	}.Run(t)
+
+	// Conversions of constants are folded at parse time
+	pipescript.TestCase{
+		Pipescript: "'42' | int",
+		Parsed:     "42",
+	}.Run(t)
+
+	pipescript.TestCase{
+		Pipescript: "int('yes')",
+		Parsed:     "error",
+	}.Run(t)
+}
+
+func TestFloat(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "float(false)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "1.5"},
+			{Timestamp: 2, Data: int64(2)},
+			{Timestamp: 3, Data: false},
+			{Timestamp: 4, Data: "NaN"},
+			{Timestamp: 5, Data: "abc"},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1.5},
+			{Timestamp: 2, Data: float64(2)},
+			{Timestamp: 3, Data: float64(0)},
+			{Timestamp: 4, Data: nil},
+			{Timestamp: 5, Data: nil},
+		},
+	}.Run(t)
+}
+
+func TestString(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "string",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1.5},
+			{Timestamp: 2, Data: true},
+			{Timestamp: 3, Data: []interface{}{1, "a"}},
+			{Timestamp: 4, Data: nil},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "1.5"},
+			{Timestamp: 2, Data: "true"},
+			{Timestamp: 3, Data: "[1,\"a\"]"},
+			{Timestamp: 4, Data: nil},
+		},
+	}.Run(t)
+}
+
+func TestBool(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "bool",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "true"},
+			{Timestamp: 2, Data: "F"},
+			{Timestamp: 3, Data: 0.0},
+			{Timestamp: 4, Data: int64(5)},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: true},
+			{Timestamp: 2, Data: false},
+			{Timestamp: 3, Data: false},
+			{Timestamp: 4, Data: true},
+		},
+	}.Run(t)
+
+	pipescript.TestCase{
+		Pipescript: "bool",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "maybe"},
+		},
+		OutputError: true,
+	}.Run(t)
+}
+
+func TestJSON(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "json_parse(false)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "{\"a\": 1}"},
+			{Timestamp: 2, Data: "[1,2]"},
+			{Timestamp: 3, Data: "oops"},
+			{Timestamp: 4, Data: 3.0},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"a": 1.0}},
+			{Timestamp: 2, Data: []interface{}{1.0, 2.0}},
+			{Timestamp: 3, Data: nil},
+			{Timestamp: 4, Data: nil},
+		},
+	}.Run(t)
+
+	pipescript.TestCase{
+		Pipescript: "json_parse",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "oops"},
+		},
+		OutputError: true,
+	}.Run(t)
+
+	pipescript.TestCase{
+		Pipescript: "json_stringify",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"a": 1.0}},
+			{Timestamp: 2, Data: "hi"},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "{\"a\":1}"},
+			{Timestamp: 2, Data: "\"hi\""},
+		},
+	}.Run(t)
+}
+
+func TestTypeof(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "typeof",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: nil},
+			{Timestamp: 2, Data: true},
+			{Timestamp: 3, Data: int64(1)},
+			{Timestamp: 4, Data: 1.5},
+			{Timestamp: 5, Data: "s"},
+			{Timestamp: 6, Data: []interface{}{}},
+			{Timestamp: 7, Data: map[string]interface{}{}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "null"},
+			{Timestamp: 2, Data: "boolean"},
+			{Timestamp: 3, Data: "number"},
+			{Timestamp: 4, Data: "number"},
+			{Timestamp: 5, Data: "string"},
+			{Timestamp: 6, Data: "array"},
+			{Timestamp: 7, Data: "object"},
+		},
+	}.Run(t)
+}
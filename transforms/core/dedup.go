@@ -0,0 +1,163 @@
+package core
+
+import (
+	"encoding/json"
+
+	"github.com/heedy/pipescript"
+	"github.com/heedy/pipescript/resources"
+)
+
+// dedupGroup holds a datapoint and its duplicates, which have the same key, and come within
+// the window of the group's first datapoint
+type dedupGroup struct {
+	dp       pipescript.Datapoint // The datapoint to return for the group
+	start    float64              // The timestamp of the first datapoint in the group
+	complete bool                 // Whether the window has passed, so no more duplicates can be added
+}
+
+type dedupIter struct {
+	args   []*pipescript.Datapoint
+	window float64
+	keep   string
+
+	active  map[string]*dedupGroup // The groups that can still get duplicates, by key
+	pending []*dedupGroup          // The groups that were not yet returned, in order of their first datapoint
+	done    bool
+}
+
+func (d *dedupIter) OneToOne() bool {
+	return false
+}
+
+// add puts a duplicate datapoint into the group
+func (d *dedupIter) add(g *dedupGroup, dp *pipescript.Datapoint) {
+	switch d.keep {
+	case "last":
+		g.dp = *dp
+	case "merge":
+		o1, ok1 := g.dp.Data.(map[string]interface{})
+		o2, ok2 := dp.Data.(map[string]interface{})
+		if !ok1 || !ok2 {
+			// Only objects can be merged, so other data keeps the latest value
+			g.dp.Data = dp.Data
+			return
+		}
+		res := make(map[string]interface{}, len(o1)+len(o2))
+		for k, v := range o1 {
+			res[k] = v
+		}
+		for k, v := range o2 {
+			res[k] = v
+		}
+		g.dp.Data = res
+	}
+}
+
+// next removes and returns the pending group with the earliest timestamp, if its datapoint can't change anymore
+func (d *dedupIter) next() *dedupGroup {
+	if len(d.pending) == 0 {
+		return nil
+	}
+	idx := 0
+	for i, g := range d.pending {
+		if g.dp.Timestamp < d.pending[idx].dp.Timestamp {
+			idx = i
+		}
+	}
+	g := d.pending[idx]
+	if !d.done && !g.complete && d.keep != "first" {
+		return nil
+	}
+	d.pending = append(d.pending[:idx], d.pending[idx+1:]...)
+	return g
+}
+
+func (d *dedupIter) Next(e *pipescript.TransformEnv, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+	for {
+		if g := d.next(); g != nil {
+			*out = g.dp
+			return out, nil
+		}
+		if d.done {
+			return nil, nil
+		}
+		dp, args, err := e.Next(d.args)
+		if err != nil {
+			return nil, err
+		}
+		if dp == nil {
+			d.done = true
+			continue
+		}
+		d.args = args
+
+		// Groups whose window has passed can't get any more duplicates
+		for k, g := range d.active {
+			if dp.Timestamp > g.start+d.window {
+				g.complete = true
+				delete(d.active, k)
+			}
+		}
+
+		b, err := json.Marshal(args[0].Data)
+		if err != nil {
+			return nil, err
+		}
+		key := string(b)
+		if g, ok := d.active[key]; ok {
+			d.add(g, dp)
+			continue
+		}
+		g := &dedupGroup{
+			dp:    *dp,
+			start: dp.Timestamp,
+		}
+		d.active[key] = g
+		d.pending = append(d.pending, g)
+	}
+}
+
+var Dedup = &pipescript.Transform{
+	Name:          "dedup",
+	Description:   "Removes duplicate datapoints, which have the same key within a time window",
+	Documentation: string(resources.MustAsset("docs/transforms/dedup.md")),
+	Args: []pipescript.TransformArg{
+		{
+			Name:        "key",
+			Description: "The value that is the same for duplicates. By default, datapoints with the same data are duplicates. Use t to remove datapoints with the same timestamp.",
+			Type:        pipescript.TransformArgType,
+			Optional:    true,
+			Default:     pipescript.IdentityPipe,
+		},
+		{
+			Name:        "window",
+			Description: "The number of seconds after a datapoint in which datapoints with the same key are duplicates. 0 only removes duplicates with the same timestamp.",
+			Type:        pipescript.ConstArgType,
+			Optional:    true,
+			Schema: map[string]interface{}{
+				"type":    "number",
+				"minimum": 0,
+				"default": 0,
+			},
+		},
+		{
+			Name:        "keep",
+			Description: "Which of the duplicates to keep: 'first', 'last', or 'merge', which combines the fields of objects",
+			Type:        pipescript.ConstArgType,
+			Optional:    true,
+			Schema: map[string]interface{}{
+				"type":    "string",
+				"enum":    []string{"first", "last", "merge"},
+				"default": "first",
+			},
+		},
+	},
+	Constructor: func(transform *pipescript.Transform, consts []interface{}, pipes []*pipescript.Pipe) (pipescript.TransformIterator, error) {
+		return &dedupIter{
+			args:   make([]*pipescript.Datapoint, 1),
+			window: consts[0].(float64),
+			keep:   consts[1].(string),
+			active: make(map[string]*dedupGroup),
+		}, nil
+	},
+}
@@ -0,0 +1,89 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/heedy/pipescript"
+)
+
+func TestDedup(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "dedup",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 5},
+			{Timestamp: 1, Data: 5},
+			{Timestamp: 1, Data: 6},
+			{Timestamp: 2, Data: 5},
+			{Timestamp: 2, Data: 5},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 5},
+			{Timestamp: 1, Data: 6},
+			{Timestamp: 2, Data: 5},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "dedup(t, keep='last')",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 5},
+			{Timestamp: 1, Data: 6},
+			{Timestamp: 2, Data: 7},
+			{Timestamp: 3, Data: 8},
+			{Timestamp: 3, Data: 9},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 6},
+			{Timestamp: 2, Data: 7},
+			{Timestamp: 3, Data: 9},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "dedup(d('id'), 10, 'merge')",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"id": 1, "a": 1}},
+			{Timestamp: 2, Data: map[string]interface{}{"id": 2, "a": 2}},
+			{Timestamp: 5, Data: map[string]interface{}{"id": 1, "b": 3}},
+			{Timestamp: 20, Data: map[string]interface{}{"id": 1, "c": 4}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"id": 1, "a": 1, "b": 3}},
+			{Timestamp: 2, Data: map[string]interface{}{"id": 2, "a": 2}},
+			{Timestamp: 20, Data: map[string]interface{}{"id": 1, "c": 4}},
+		},
+	}.Run(t)
+
+	// The output stays ordered when the kept datapoints are from later in the window
+	pipescript.TestCase{
+		Pipescript: "dedup(d('id'), window=10, keep='last')",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"id": 1, "v": 1}},
+			{Timestamp: 2, Data: map[string]interface{}{"id": 2, "v": 2}},
+			{Timestamp: 5, Data: map[string]interface{}{"id": 1, "v": 3}},
+			{Timestamp: 30, Data: map[string]interface{}{"id": 2, "v": 4}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 2, Data: map[string]interface{}{"id": 2, "v": 2}},
+			{Timestamp: 5, Data: map[string]interface{}{"id": 1, "v": 3}},
+			{Timestamp: 30, Data: map[string]interface{}{"id": 2, "v": 4}},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "dedup(d, 1m)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 0, Data: "on"},
+			{Timestamp: 30, Data: "on"},
+			{Timestamp: 59, Data: "off"},
+			{Timestamp: 90, Data: "on"},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 0, Data: "on"},
+			{Timestamp: 59, Data: "off"},
+			{Timestamp: 90, Data: "on"},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "dedup(keep='second')",
+		Parsed:     "error",
+	}.Run(t)
+}
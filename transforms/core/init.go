@@ -8,6 +8,23 @@ func Register() {
 	Where.Register()
 	I.Register()
 	Map.Register()
+	Partition.Register()
+	Groupby.Register()
 	Reduce.Register()
 	While.Register()
+	Reorder.Register()
+	AssertOrdered.Register()
+	Dedup.Register()
+
+	Isnull.Register()
+	Coalesce.Register()
+	Default.Register()
+
+	ToInt.Register()
+	ToFloat.Register()
+	ToString.Register()
+	ToBool.Register()
+	JSONParse.Register()
+	JSONStringify.Register()
+	Typeof.Register()
 }
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"github.com/heedy/pipescript"
+	"github.com/heedy/pipescript/resources"
+)
+
+var Isnull = &pipescript.Transform{
+	Name:          "isnull",
+	Description:   "Returns true if the datapoint's data is null",
+	Documentation: string(resources.MustAsset("docs/transforms/isnull.md")),
+	OutputSchema: map[string]interface{}{
+		"type": "boolean",
+	},
+	Constructor: pipescript.NewBasic(nil, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		out.Data = dp.Data == nil
+		return out, nil
+	}),
+}
+
+var Coalesce = &pipescript.Transform{
+	Name:          "coalesce",
+	Description:   "Returns the first of its arguments that is not null",
+	Documentation: string(resources.MustAsset("docs/transforms/coalesce.md")),
+	Args: []pipescript.TransformArg{
+		{
+			Description: "The value to return if it is not null",
+			Type:        pipescript.TransformArgType,
+		},
+		{
+			Description: "The values to try in order if the first argument is null",
+			Type:        pipescript.TransformArgType,
+			Variadic:    true,
+		},
+	},
+	Constructor: pipescript.NewArgBasic(func(args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		out.Data = nil
+		for _, a := range args {
+			if a.Data != nil {
+				out.Data = a.Data
+				break
+			}
+		}
+		return out, nil
+	}),
+}
+
+var Default = &pipescript.Transform{
+	Name:          "default",
+	Description:   "Replaces null data with the given value",
+	Documentation: string(resources.MustAsset("docs/transforms/default.md")),
+	Args: []pipescript.TransformArg{
+		{
+			Description: "The value to use when the datapoint's data is null",
+			Type:        pipescript.TransformArgType,
+		},
+	},
+	Constructor: pipescript.NewBasic(nil, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		if dp.Data == nil {
+			out.Data = args[0].Data
+		} else {
+			out.Data = dp.Data
+		}
+		return out, nil
+	}),
+}
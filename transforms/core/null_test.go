@@ -0,0 +1,77 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/heedy/pipescript"
+)
+
+func TestIsnull(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "d('a') | isnull",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"a": 1}},
+			{Timestamp: 2, Data: map[string]interface{}{"b": 1}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: false},
+			{Timestamp: 2, Data: true},
+		},
+	}.Run(t)
+}
+
+func TestCoalesce(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "coalesce(d('a'),d('b'))",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"a": 1, "b": 2}},
+			{Timestamp: 2, Data: map[string]interface{}{"b": 2}},
+			{Timestamp: 3, Data: map[string]interface{}{}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1},
+			{Timestamp: 2, Data: 2},
+			{Timestamp: 3, Data: nil},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "coalesce(d('a'),d('b'),d('c'))",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"a": 1, "c": 3}},
+			{Timestamp: 2, Data: map[string]interface{}{"c": 3}},
+			{Timestamp: 3, Data: map[string]interface{}{}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1},
+			{Timestamp: 2, Data: 3},
+			{Timestamp: 3, Data: nil},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "coalesce(null,5)",
+		Parsed:     "5",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: float64(5)},
+		},
+	}.Run(t)
+}
+
+func TestDefault(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "d('a') | default(0) + 1",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"a": 1}},
+			{Timestamp: 2, Data: map[string]interface{}{"b": 1}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: float64(2)},
+			{Timestamp: 2, Data: float64(1)},
+		},
+	}.Run(t)
+}
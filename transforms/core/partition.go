@@ -0,0 +1,274 @@
+package core
+
+import (
+	"math"
+
+	"github.com/heedy/pipescript"
+	"github.com/heedy/pipescript/resources"
+)
+
+// partitionKey runs the pipe of one key of the partition, holding its outputs until they can be returned in order
+type partitionKey struct {
+	key      interface{}
+	cp       *pipescript.ChannelPipe
+	oneToOne bool
+
+	// The timestamps of the datapoints sent to the pipe that were not yet answered by an output. Each output of a
+	// one-to-one pipe answers one datapoint. Other pipes are assumed to return outputs with the timestamps of their
+	// inputs, like where or dedup, or of the first input of a group, like while or aggregators, so that an output
+	// answers all datapoints before it, and one datapoint at its timestamp.
+	pending []float64
+	out     []*pipescript.Datapoint
+	ended   bool // Whether the end of the stream was sent to the pipe
+	done    bool // Whether the pipe returned the end of its stream
+}
+
+func (k *partitionKey) receive(res pipescript.ChanResult) error {
+	if res.Err != nil {
+		return res.Err
+	}
+	if res.DP == nil {
+		k.done = true
+		return nil
+	}
+	k.out = append(k.out, res.DP)
+	if k.oneToOne {
+		if len(k.pending) > 0 {
+			k.pending = k.pending[1:]
+		}
+		return nil
+	}
+	for len(k.pending) > 0 && k.pending[0] < res.DP.Timestamp {
+		k.pending = k.pending[1:]
+	}
+	if len(k.pending) > 0 && k.pending[0] == res.DP.Timestamp {
+		k.pending = k.pending[1:]
+	}
+	return nil
+}
+
+// send sends the datapoint to the pipe, receiving the pipe's outputs while waiting. A nil datapoint ends the stream.
+func (k *partitionKey) send(dp *pipescript.Datapoint) error {
+	if dp == nil {
+		k.ended = true
+	} else {
+		k.pending = append(k.pending, dp.Timestamp)
+	}
+	for !k.done {
+		select {
+		case res := <-k.cp.Receiver:
+			if err := k.receive(res); err != nil {
+				return err
+			}
+		case k.cp.Sender <- dp:
+			return nil
+		}
+	}
+	return nil
+}
+
+// poll receives the outputs that the pipe has ready, without waiting
+func (k *partitionKey) poll() error {
+	for !k.done {
+		select {
+		case res := <-k.cp.Receiver:
+			if err := k.receive(res); err != nil {
+				return err
+			}
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// earliest returns the earliest timestamp that the pipe could still return, given that all future input
+// has timestamps of at least next. It returns -Inf if the pipe could return anything.
+func (k *partitionKey) earliest(next float64) float64 {
+	switch {
+	case len(k.out) > 0:
+		return k.out[0].Timestamp
+	case k.done:
+		return math.Inf(1)
+	case len(k.pending) > 0:
+		return k.pending[0]
+	case !k.ended:
+		return next
+	}
+	return math.Inf(-1)
+}
+
+type partitionIter struct {
+	pipe *pipescript.Pipe
+	tag  bool
+	args []*pipescript.Datapoint
+
+	keys   []*partitionKey // The keys in the order that they were first seen, which breaks ties between outputs
+	keymap map[string]*partitionKey
+	next   float64 // The timestamp of the most recent input, which no future input is before
+	ended  bool
+}
+
+func (p *partitionIter) OneToOne() bool {
+	return false
+}
+
+func (p *partitionIter) close() {
+	for _, k := range p.keys {
+		k.cp.Close()
+	}
+	p.keys = nil
+}
+
+// pop returns the key whose output comes next, if no pipe can return an earlier one. Outputs with the same
+// timestamp are returned in the order that their keys were first seen, so a key can only return an output
+// once the keys before it can't return one with the same timestamp.
+func (p *partitionIter) pop() *partitionKey {
+	next := p.next
+	if p.ended {
+		next = math.Inf(1)
+	}
+	var first *partitionKey
+	earliest := math.Inf(1)
+	for _, k := range p.keys {
+		if e := k.earliest(next); e < earliest {
+			first = k
+			earliest = e
+		}
+	}
+	// Keys that are seen later come after all of the existing keys, so they only need to have later timestamps
+	if first == nil || len(first.out) == 0 || earliest > next {
+		return nil
+	}
+	return first
+}
+
+func (p *partitionIter) Next(e *pipescript.TransformEnv, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+	for {
+		for _, k := range p.keys {
+			if err := k.poll(); err != nil {
+				p.close()
+				return nil, err
+			}
+		}
+		if k := p.pop(); k != nil {
+			dp := k.out[0]
+			k.out = k.out[1:]
+			out.Timestamp = dp.Timestamp
+			out.Duration = dp.Duration
+			out.Data = dp.Data
+			if p.tag {
+				out.Data = map[string]interface{}{
+					"key":  k.key,
+					"data": dp.Data,
+				}
+			}
+			return out, nil
+		}
+
+		if !p.ended {
+			dp, args, err := e.Next(p.args)
+			if err != nil {
+				p.close()
+				return nil, err
+			}
+			p.args = args
+			if dp == nil {
+				// Send the end of the stream to all pipes, so that they return their remaining outputs
+				p.ended = true
+				for _, k := range p.keys {
+					if err = k.send(nil); err != nil {
+						p.close()
+						return nil, err
+					}
+				}
+				continue
+			}
+			p.next = dp.Timestamp
+			keystr := args[0].ToString()
+			k, ok := p.keymap[keystr]
+			if !ok {
+				pipe := p.pipe.Copy()
+				k = &partitionKey{
+					key:      args[0].Data,
+					cp:       pipescript.NewChannelPipe(pipe),
+					oneToOne: pipe.OneToOne(),
+				}
+				p.keymap[keystr] = k
+				p.keys = append(p.keys, k)
+			}
+			if err = k.send(dp); err != nil {
+				p.close()
+				return nil, err
+			}
+			continue
+		}
+
+		// The input ended, so wait for a pipe that has not yet returned all of its outputs
+		var waiting *partitionKey
+		for _, k := range p.keys {
+			if !k.done && len(k.out) == 0 {
+				waiting = k
+				break
+			}
+		}
+		if waiting == nil {
+			p.close()
+			return nil, nil
+		}
+		if err := waiting.receive(<-waiting.cp.Receiver); err != nil {
+			p.close()
+			return nil, err
+		}
+	}
+}
+
+func partitionArgs() []pipescript.TransformArg {
+	return []pipescript.TransformArg{
+		{
+			Name:        "key",
+			Description: "The value to split on. This must be something that can be converted to string.",
+			Type:        pipescript.TransformArgType,
+		},
+		{
+			Name:        "pipe",
+			Description: "The transform to instantiate for each different value of the key",
+			Type:        pipescript.PipeArgType,
+		},
+		{
+			Name:        "tag",
+			Description: "Whether to return objects with the key and the data of each output, instead of only the data",
+			Type:        pipescript.ConstArgType,
+			Optional:    true,
+			Schema: map[string]interface{}{
+				"type":    "boolean",
+				"default": false,
+			},
+		},
+	}
+}
+
+func partitionConstructor(transform *pipescript.Transform, consts []interface{}, pipes []*pipescript.Pipe) (pipescript.TransformIterator, error) {
+	return &partitionIter{
+		pipe:   pipes[0],
+		tag:    consts[0].(bool),
+		args:   make([]*pipescript.Datapoint, 1),
+		keymap: make(map[string]*partitionKey),
+	}, nil
+}
+
+var Partition = &pipescript.Transform{
+	Name:          "partition",
+	Description:   "Splits the timeseries by the first arg, running the pipe in the second arg separately on each part, and returns all of their outputs in order",
+	Documentation: string(resources.MustAsset("docs/transforms/partition.md")),
+	Args:          partitionArgs(),
+	Constructor:   partitionConstructor,
+}
+
+var Groupby = &pipescript.Transform{
+	Name:          "groupby",
+	Description:   "The same as partition: runs the pipe in the second arg separately for each value of the first arg, and returns all of their outputs in order",
+	Documentation: string(resources.MustAsset("docs/transforms/groupby.md")),
+	Args:          partitionArgs(),
+	Constructor:   partitionConstructor,
+}
@@ -0,0 +1,122 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"github.com/heedy/pipescript"
+	"github.com/stretchr/testify/require"
+)
+
+var devices = []pipescript.Datapoint{
+	{Timestamp: 1, Data: map[string]interface{}{"id": "a", "v": 1}},
+	{Timestamp: 2, Data: map[string]interface{}{"id": "b", "v": 5}},
+	{Timestamp: 3, Data: map[string]interface{}{"id": "a", "v": 1}},
+	{Timestamp: 4, Data: map[string]interface{}{"id": "b", "v": 6}},
+	{Timestamp: 5, Data: map[string]interface{}{"id": "a", "v": 2}},
+	{Timestamp: 6, Data: map[string]interface{}{"id": "b", "v": 6}},
+}
+
+func TestPartition(t *testing.T) {
+	Register()
+
+	pipescript.TestCase{
+		Pipescript: "partition(d(\"id\"), i)",
+		Input:      devices,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: int64(0)},
+			{Timestamp: 2, Data: int64(0)},
+			{Timestamp: 3, Data: int64(1)},
+			{Timestamp: 4, Data: int64(1)},
+			{Timestamp: 5, Data: int64(2)},
+			{Timestamp: 6, Data: int64(2)},
+		},
+	}.Run(t)
+
+	pipescript.TestCase{
+		// dedup holds datapoints, so the outputs of different keys arrive out of order
+		Pipescript: "partition(d(\"id\"), d(\"v\"):dedup(window=10), true)",
+		Input:      devices,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"key": "a", "data": 1}},
+			{Timestamp: 2, Data: map[string]interface{}{"key": "b", "data": 5}},
+			{Timestamp: 4, Data: map[string]interface{}{"key": "b", "data": 6}},
+			{Timestamp: 5, Data: map[string]interface{}{"key": "a", "data": 2}},
+		},
+	}.Run(t)
+
+	pipescript.TestCase{
+		// Aggregators return their outputs at the end of the stream
+		Pipescript: "groupby(d(\"v\") > 4, map(d(\"id\"), i), tag=true)",
+		Input:      devices,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Duration: 4, Data: map[string]interface{}{"key": false, "data": map[string]interface{}{"a": int64(2)}}},
+			{Timestamp: 2, Duration: 4, Data: map[string]interface{}{"key": true, "data": map[string]interface{}{"b": int64(2)}}},
+		},
+	}.Run(t)
+
+	pipescript.TestCase{
+		// Outputs with the same timestamp are returned in the order that their keys were first seen
+		Pipescript: "partition(d(\"id\"), d(\"v\"), tag=true)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"id": "a", "v": 1}},
+			{Timestamp: 1, Data: map[string]interface{}{"id": "b", "v": 2}},
+			{Timestamp: 1, Data: map[string]interface{}{"id": "c", "v": 3}},
+			{Timestamp: 2, Data: map[string]interface{}{"id": "b", "v": 4}},
+			{Timestamp: 2, Data: map[string]interface{}{"id": "a", "v": 5}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"key": "a", "data": 1}},
+			{Timestamp: 1, Data: map[string]interface{}{"key": "b", "data": 2}},
+			{Timestamp: 1, Data: map[string]interface{}{"key": "c", "data": 3}},
+			{Timestamp: 2, Data: map[string]interface{}{"key": "a", "data": 5}},
+			{Timestamp: 2, Data: map[string]interface{}{"key": "b", "data": 4}},
+		},
+	}.Run(t)
+
+	pipescript.TestCase{
+		Pipescript:  "partition(d(\"id\"), d(\"id\"):float(strict=true))",
+		Input:       devices,
+		OutputError: true,
+	}.Run(t)
+
+	pipescript.TestCase{
+		Pipescript: "partition(d(\"id\"))",
+		Parsed:     "error",
+	}.Run(t)
+}
+
+func TestPartitionPop(t *testing.T) {
+	a := &partitionKey{key: "a", oneToOne: true, pending: []float64{1}}
+	b := &partitionKey{key: "b", oneToOne: true, out: []*pipescript.Datapoint{{Timestamp: 1}}}
+	p := &partitionIter{keys: []*partitionKey{a, b}, next: 1}
+
+	// a was seen first, and can still return an output at the same timestamp as b
+	require.Nil(t, p.pop())
+	a.pending = nil
+	a.out = []*pipescript.Datapoint{{Timestamp: 1}}
+	require.Equal(t, a, p.pop())
+}
+
+func TestPartitionEarliest(t *testing.T) {
+	// A pipe that is not one-to-one can't return anything before the oldest datapoint it did not answer
+	k := &partitionKey{pending: []float64{1, 2, 2, 3}}
+	require.Equal(t, float64(1), k.earliest(3))
+
+	// An output answers the datapoints before it, and one at its timestamp
+	require.NoError(t, k.receive(pipescript.ChanResult{DP: &pipescript.Datapoint{Timestamp: 2}}))
+	require.Equal(t, []float64{2, 3}, k.pending)
+	k.out = nil
+	require.Equal(t, float64(2), k.earliest(3))
+
+	// Once all datapoints were answered, outputs can only come from future datapoints
+	require.NoError(t, k.receive(pipescript.ChanResult{DP: &pipescript.Datapoint{Timestamp: 3}}))
+	k.out = nil
+	require.Equal(t, float64(4), k.earliest(4))
+
+	// After the end of the stream was sent, the pipe can return anything until it is done
+	k.ended = true
+	require.Equal(t, math.Inf(-1), k.earliest(4))
+	require.NoError(t, k.receive(pipescript.ChanResult{}))
+	require.Equal(t, math.Inf(1), k.earliest(4))
+}
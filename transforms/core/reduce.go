@@ -7,24 +7,6 @@ import (
 	"github.com/heedy/pipescript/resources"
 )
 
-type arrIterator struct {
-	Timestamp float64
-	Duration  float64
-	vals      []interface{}
-	idx       int
-}
-
-func (ai *arrIterator) Next(out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
-	if ai.idx >= len(ai.vals) {
-		return nil, nil
-	}
-	out.Timestamp = ai.Timestamp
-	out.Duration = ai.Duration
-	out.Data = ai.vals[ai.idx]
-	ai.idx++
-	return out, nil
-}
-
 var Reduce = &pipescript.Transform{
 	Name:        "reduce",
 	Description: "Takes a json object, and considers each field to be a separate datapoint's data. It then runs the transform in its argument over the elements",
@@ -36,23 +18,20 @@ var Reduce = &pipescript.Transform{
 	},
 	Documentation: string(resources.MustAsset("docs/transforms/reduce.md")),
 	Constructor: pipescript.NewBasic(nil, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
-		ai := &arrIterator{
-			Timestamp: dp.Timestamp,
-			Duration:  dp.Duration,
-		}
+		var vals []interface{}
 		switch d := dp.Data.(type) {
 		case []interface{}:
-			ai.vals = d
+			vals = d
 		case map[string]interface{}:
-			ai.vals = make([]interface{}, 0, len(d))
+			vals = make([]interface{}, 0, len(d))
 			for _, v := range d {
-				ai.vals = append(ai.vals, v)
+				vals = append(vals, v)
 			}
 		default:
 			return nil, errors.New("Can't reduce non-object/array datapoint")
 		}
 		p := pipes[0].Copy()
-		p.InputIterator(ai)
+		p.InputIterator(pipescript.NewValueArrayIterator(dp, vals))
 
 		var data interface{}
 		for {
@@ -0,0 +1,92 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/heedy/pipescript"
+	"github.com/heedy/pipescript/resources"
+)
+
+type reorderIter struct {
+	maxlag   float64
+	maxcount int
+	ri       *pipescript.ReorderIterator
+}
+
+func (r *reorderIter) OneToOne() bool {
+	return false
+}
+
+func (r *reorderIter) Next(e *pipescript.TransformEnv, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+	if r.ri == nil {
+		r.ri = pipescript.NewReorderIterator(pipescript.IteratorFromBI{BI: e.Iter}, r.maxlag, r.maxcount)
+	}
+	return r.ri.Next(out)
+}
+
+var Reorder = &pipescript.Transform{
+	Name:          "reorder",
+	Description:   "Sorts datapoints that arrive slightly out of order by timestamp",
+	Documentation: string(resources.MustAsset("docs/transforms/reorder.md")),
+	Args: []pipescript.TransformArg{
+		{
+			Name:        "maxlag",
+			Description: "The number of seconds that a datapoint can arrive after newer datapoints. 0 sorts the entire stream.",
+			Type:        pipescript.ConstArgType,
+			Schema: map[string]interface{}{
+				"type":    "number",
+				"minimum": 0,
+			},
+		},
+		{
+			Name:        "count",
+			Description: "The maximum number of datapoints to hold while waiting. 0 has no limit.",
+			Type:        pipescript.ConstArgType,
+			Optional:    true,
+			Default:     pipescript.MustPipe(pipescript.NewConstTransform(0), nil),
+			Schema: map[string]interface{}{
+				"type":    "integer",
+				"minimum": 0,
+			},
+		},
+	},
+	Constructor: func(transform *pipescript.Transform, consts []interface{}, pipes []*pipescript.Pipe) (pipescript.TransformIterator, error) {
+		return &reorderIter{
+			maxlag:   consts[0].(float64),
+			maxcount: int(consts[1].(int64)),
+		}, nil
+	},
+}
+
+type assertOrderedIter struct {
+	last    float64
+	started bool
+}
+
+func (a *assertOrderedIter) OneToOne() bool {
+	return true
+}
+
+func (a *assertOrderedIter) Next(e *pipescript.TransformEnv, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+	dp, _, err := e.Next(nil)
+	if err != nil || dp == nil {
+		return dp, err
+	}
+	if a.started && dp.Timestamp < a.last {
+		return nil, fmt.Errorf("assert_ordered: datapoint with timestamp %v came after timestamp %v", dp.Timestamp, a.last)
+	}
+	a.last = dp.Timestamp
+	a.started = true
+	out.Timestamp = dp.Timestamp
+	out.Duration = dp.Duration
+	out.Data = dp.Data
+	return out, nil
+}
+
+var AssertOrdered = &pipescript.Transform{
+	Name:        "assert_ordered",
+	Description: "Passes through datapoints unchanged, but fails with an error if a timestamp is earlier than the one before it",
+	Constructor: func(transform *pipescript.Transform, consts []interface{}, pipes []*pipescript.Pipe) (pipescript.TransformIterator, error) {
+		return &assertOrderedIter{}, nil
+	},
+}
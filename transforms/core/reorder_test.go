@@ -0,0 +1,93 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/heedy/pipescript"
+)
+
+func TestReorder(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "reorder(2)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1},
+			{Timestamp: 3, Data: 3},
+			{Timestamp: 2, Data: 2},
+			{Timestamp: 5, Data: 5},
+			{Timestamp: 4, Data: 4},
+			{Timestamp: 10, Data: 10},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1},
+			{Timestamp: 2, Data: 2},
+			{Timestamp: 3, Data: 3},
+			{Timestamp: 4, Data: 4},
+			{Timestamp: 5, Data: 5},
+			{Timestamp: 10, Data: 10},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "reorder(1m, count=1) | d + 1",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 2, Data: 2},
+			{Timestamp: 1, Data: 1},
+			{Timestamp: 4, Data: 4},
+			{Timestamp: 3, Data: 3},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: float64(2)},
+			{Timestamp: 2, Data: float64(3)},
+			{Timestamp: 3, Data: float64(4)},
+			{Timestamp: 4, Data: float64(5)},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "reorder(2)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1},
+			{Timestamp: 5, Data: 5},
+			{Timestamp: 8, Data: 8},
+			{Timestamp: 2, Data: 2},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1},
+			{Timestamp: 5, Data: 5},
+		},
+		OutputError: true,
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "reorder(-1)",
+		Parsed:     "error",
+	}.Run(t)
+}
+
+func TestAssertOrdered(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "assert_ordered",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1},
+			{Timestamp: 1, Data: 2},
+			{Timestamp: 3, Data: 3},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1},
+			{Timestamp: 1, Data: 2},
+			{Timestamp: 3, Data: 3},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "assert_ordered",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1},
+			{Timestamp: 3, Data: 3},
+			{Timestamp: 2, Data: 2},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1},
+			{Timestamp: 3, Data: 3},
+		},
+		OutputError: true,
+	}.Run(t)
+}
@@ -164,3 +164,149 @@ var Yearday = &pipescript.Transform{
 		return out, nil
 	}),
 }
+
+var Minute = &pipescript.Transform{
+	Name:        "minute",
+	Description: "Returns the number of minutes since Jan 1 1970 in the given time zone.",
+	Args: []pipescript.TransformArg{
+		timezoneArg,
+	},
+	OutputSchema: map[string]interface{}{
+		"type": "integer",
+	},
+	Constructor: NewTimeBasic(0, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, tz *time.Location, st time.Time, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		tval := dp.Time().In(tz)
+		out.Data = int64(tval.Sub(st) / time.Minute)
+		return out, nil
+	}),
+}
+
+var Hourminute = &pipescript.Transform{
+	Name:        "hourminute",
+	Description: "Returns the minute in the hour during which the datapoint happened [0,59].",
+	Args: []pipescript.TransformArg{
+		timezoneArg,
+	},
+	OutputSchema: map[string]interface{}{
+		"type": "integer",
+	},
+	Constructor: NewTimeBasic(0, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, tz *time.Location, st time.Time, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		tval := dp.Time().In(tz)
+		out.Data = tval.Minute()
+		return out, nil
+	}),
+}
+
+var Second = &pipescript.Transform{
+	Name:        "second",
+	Description: "Returns the second in the minute during which the datapoint happened [0,59].",
+	Args: []pipescript.TransformArg{
+		timezoneArg,
+	},
+	OutputSchema: map[string]interface{}{
+		"type": "integer",
+	},
+	Constructor: NewTimeBasic(0, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, tz *time.Location, st time.Time, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		tval := dp.Time().In(tz)
+		out.Data = tval.Second()
+		return out, nil
+	}),
+}
+
+var Timeofday = &pipescript.Transform{
+	Name:        "timeofday",
+	Description: "Returns the number of seconds since midnight in the given time zone.",
+	Args: []pipescript.TransformArg{
+		timezoneArg,
+	},
+	OutputSchema: map[string]interface{}{
+		"type": "number",
+	},
+	Constructor: NewTimeBasic(0, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, tz *time.Location, st time.Time, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		tval := dp.Time().In(tz)
+		midnight := time.Date(tval.Year(), tval.Month(), tval.Day(), 0, 0, 0, 0, tz)
+		out.Data = tval.Sub(midnight).Seconds()
+		return out, nil
+	}),
+}
+
+var Quarter = &pipescript.Transform{
+	Name:        "quarter",
+	Description: "Returns the quarter of the year during which the datapoint happened [1,4].",
+	Args: []pipescript.TransformArg{
+		timezoneArg,
+	},
+	OutputSchema: map[string]interface{}{
+		"type": "integer",
+	},
+	Constructor: NewTimeBasic(0, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, tz *time.Location, st time.Time, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		tval := dp.Time().In(tz)
+		out.Data = (int(tval.Month())-1)/3 + 1
+		return out, nil
+	}),
+}
+
+var Isoweek = &pipescript.Transform{
+	Name:        "isoweek",
+	Description: "Returns the ISO 8601 week number of the year during which the datapoint happened [1,53]. Use with isoyear.",
+	Args: []pipescript.TransformArg{
+		timezoneArg,
+	},
+	OutputSchema: map[string]interface{}{
+		"type": "integer",
+	},
+	Constructor: NewTimeBasic(0, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, tz *time.Location, st time.Time, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		_, week := dp.Time().In(tz).ISOWeek()
+		out.Data = week
+		return out, nil
+	}),
+}
+
+var Isoyear = &pipescript.Transform{
+	Name:        "isoyear",
+	Description: "Returns the ISO 8601 year of the week during which the datapoint happened, which can differ from the calendar year in early January and late December.",
+	Args: []pipescript.TransformArg{
+		timezoneArg,
+	},
+	OutputSchema: map[string]interface{}{
+		"type": "integer",
+	},
+	Constructor: NewTimeBasic(0, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, tz *time.Location, st time.Time, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		year, _ := dp.Time().In(tz).ISOWeek()
+		out.Data = year
+		return out, nil
+	}),
+}
+
+var Isweekend = &pipescript.Transform{
+	Name:        "isweekend",
+	Description: "Returns true if the datapoint happened on a Saturday or Sunday.",
+	Args: []pipescript.TransformArg{
+		timezoneArg,
+	},
+	OutputSchema: map[string]interface{}{
+		"type": "boolean",
+	},
+	Constructor: NewTimeBasic(0, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, tz *time.Location, st time.Time, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		wd := dp.Time().In(tz).Weekday()
+		out.Data = wd == time.Saturday || wd == time.Sunday
+		return out, nil
+	}),
+}
+
+var Daysinmonth = &pipescript.Transform{
+	Name:        "daysinmonth",
+	Description: "Returns the number of days in the month during which the datapoint happened.",
+	Args: []pipescript.TransformArg{
+		timezoneArg,
+	},
+	OutputSchema: map[string]interface{}{
+		"type": "integer",
+	},
+	Constructor: NewTimeBasic(0, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, tz *time.Location, st time.Time, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		tval := dp.Time().In(tz)
+		// Day 0 of the next month is the last day of this month
+		out.Data = time.Date(tval.Year(), tval.Month()+1, 0, 0, 0, 0, 0, tz).Day()
+		return out, nil
+	}),
+}
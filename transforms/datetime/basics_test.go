@@ -48,6 +48,15 @@ func TestDayhour(t *testing.T) {
 func TestHour(t *testing.T) {
 	Register()
 
+	pipescript.TestCase{
+		Pipescript: "hour(timezone='UTC')",
+		Input: []pipescript.Datapoint{
+			{Timestamp: float64(1454907600), Data: 1},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1454907600, Data: int64(404141)},
+		},
+	}.Run(t)
 	pipescript.TestCase{
 		Pipescript: "hour('UTC')",
 		Input: []pipescript.Datapoint{
@@ -185,3 +194,116 @@ func TestYearMonth(t *testing.T) {
 		},
 	}.Run(t)
 }
+
+func TestMinuteSecond(t *testing.T) {
+	Register()
+
+	pipescript.TestCase{
+		Pipescript: "minute('lol')",
+		Parsed:     "error",
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "{'m': minute('UTC'), 'hm': hourminute('UTC'), 's': second('UTC')}",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1577881845, Data: 1},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1577881845, Data: map[string]interface{}{"m": int64(26298030), "hm": 30, "s": 45}},
+		},
+	}.Run(t)
+}
+
+func TestTimeofday(t *testing.T) {
+	Register()
+
+	pipescript.TestCase{
+		Pipescript: "timeofday('lol')",
+		Parsed:     "error",
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "timeofday('UTC')",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1577881845.5, Data: 1},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1577881845.5, Data: 45045.5},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "timeofday('America/New_York')",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1577881845, Data: 1},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1577881845, Data: float64(27045)}, // Takes time zone into account
+		},
+	}.Run(t)
+}
+
+func TestQuarter(t *testing.T) {
+	Register()
+
+	pipescript.TestCase{
+		Pipescript: "quarter('UTC')",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1454907600, Data: 1},
+			{Timestamp: 1609459199, Data: 1},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1454907600, Data: 1},
+			{Timestamp: 1609459199, Data: 4},
+		},
+	}.Run(t)
+}
+
+func TestIsoweek(t *testing.T) {
+	Register()
+
+	pipescript.TestCase{
+		Pipescript: "isoweek('lol')",
+		Parsed:     "error",
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "{'w': isoweek('UTC'), 'y': isoyear('UTC')}",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1454907600, Data: 1},
+			{Timestamp: 1609459200, Data: 1}, // Jan 1 2021 is in the last week of 2020
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1454907600, Data: map[string]interface{}{"w": 6, "y": 2016}},
+			{Timestamp: 1609459200, Data: map[string]interface{}{"w": 53, "y": 2020}},
+		},
+	}.Run(t)
+}
+
+func TestIsweekend(t *testing.T) {
+	Register()
+
+	pipescript.TestCase{
+		Pipescript: "isweekend('UTC')",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1454821200, Data: 1},
+			{Timestamp: 1454907600, Data: 1},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1454821200, Data: true},
+			{Timestamp: 1454907600, Data: false},
+		},
+	}.Run(t)
+}
+
+func TestDaysinmonth(t *testing.T) {
+	Register()
+
+	pipescript.TestCase{
+		Pipescript: "daysinmonth('UTC')",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1454907600, Data: 1},
+			{Timestamp: 1609459199, Data: 1},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1454907600, Data: 29},
+			{Timestamp: 1609459199, Data: 31},
+		},
+	}.Run(t)
+}
@@ -0,0 +1,355 @@
+package datetime
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/heedy/pipescript"
+	"github.com/heedy/pipescript/resources"
+)
+
+// strftimeDirectives gives the Go time layout corresponding to each supported strftime directive
+var strftimeDirectives = map[byte]string{
+	'Y': "2006",
+	'y': "06",
+	'm': "01",
+	'd': "02",
+	'e': "_2",
+	'j': "002",
+	'H': "15",
+	'I': "03",
+	'M': "04",
+	'S': "05",
+	'p': "PM",
+	'b': "Jan",
+	'h': "Jan",
+	'B': "January",
+	'a': "Mon",
+	'A': "Monday",
+	'z': "-0700",
+	'Z': "MST",
+}
+
+// strftimeCombined gives the directives that are equivalent to several others
+var strftimeCombined = map[byte]string{
+	'F': "%Y-%m-%d",
+	'T': "%H:%M:%S",
+}
+
+// formatElement is either a strftime directive with its Go time layout, or literal text
+type formatElement struct {
+	text      string
+	literal   bool
+	directive byte
+}
+
+// timeFormat is a parsed strftime format string
+type timeFormat []formatElement
+
+func parseTimeFormat(v interface{}) (timeFormat, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, errors.New("Time format must be a string")
+	}
+	var tf timeFormat
+	for i := 0; i < len(s); i++ {
+		if s[i] != '%' {
+			j := strings.IndexByte(s[i:], '%')
+			if j < 0 {
+				j = len(s) - i
+			}
+			tf = append(tf, formatElement{text: s[i : i+j], literal: true})
+			i += j - 1
+			continue
+		}
+		i++
+		if i == len(s) {
+			return nil, errors.New("Time format can't end with '%'")
+		}
+		if s[i] == '%' {
+			tf = append(tf, formatElement{text: "%", literal: true})
+			continue
+		}
+		if c, ok := strftimeCombined[s[i]]; ok {
+			ctf, _ := parseTimeFormat(c)
+			tf = append(tf, ctf...)
+			continue
+		}
+		layout, ok := strftimeDirectives[s[i]]
+		if !ok {
+			return nil, fmt.Errorf("Unsupported time format directive '%%%c'", s[i])
+		}
+		tf = append(tf, formatElement{text: layout, directive: s[i]})
+	}
+	return tf, nil
+}
+
+// Format writes the time in the format. Literal text is copied directly, so it can safely contain
+// characters that have a special meaning in Go time layouts.
+func (tf timeFormat) Format(t time.Time) string {
+	var b strings.Builder
+	for _, f := range tf {
+		if f.literal {
+			b.WriteString(f.text)
+		} else {
+			b.WriteString(t.Format(f.text))
+		}
+	}
+	return b.String()
+}
+
+var (
+	monthNames   = []string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"}
+	weekdayNames = []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+)
+
+// parseNumber reads a number of at most maxDigits digits from the start of the string, which doesn't need
+// to be zero-padded, and checks that it is between min and max
+func parseNumber(s string, maxDigits, min, max int) (int, string, error) {
+	n, i := 0, 0
+	for ; i < len(s) && i < maxDigits && s[i] >= '0' && s[i] <= '9'; i++ {
+		n = n*10 + int(s[i]-'0')
+	}
+	if i == 0 {
+		return 0, s, errors.New("expected a number")
+	}
+	if n < min || n > max {
+		return 0, s, fmt.Errorf("%d is out of range", n)
+	}
+	return n, s[i:], nil
+}
+
+// parseName reads one of the names from the start of the string, ignoring case, and returns its index.
+// The names can be abbreviated to their first three letters.
+func parseName(s string, names []string) (int, string, error) {
+	for i, name := range names {
+		if len(s) >= len(name) && strings.EqualFold(s[:len(name)], name) {
+			return i, s[len(name):], nil
+		}
+	}
+	for i, name := range names {
+		if len(s) >= 3 && strings.EqualFold(s[:3], name[:3]) {
+			return i, s[3:], nil
+		}
+	}
+	return 0, s, errors.New("expected a name")
+}
+
+// parsedTime holds the values read by the directives of a time format
+type parsedTime struct {
+	year, month, day, yday int
+	hour, min, sec         int
+	pm                     int // 0 if there is no AM/PM, 1 for AM, and 2 for PM
+	zone                   *time.Location
+	zoneName               string
+}
+
+// parseDirective reads the value of a directive from the start of the string, returning the rest of the string
+func (pt *parsedTime) parseDirective(d byte, s string) (rest string, err error) {
+	switch d {
+	case 'Y':
+		pt.year, rest, err = parseNumber(s, 4, 0, 9999)
+	case 'y':
+		pt.year, rest, err = parseNumber(s, 2, 0, 99)
+		// The same as Go and POSIX: 69-99 are in the 1900s, and 00-68 in the 2000s
+		if pt.year >= 69 {
+			pt.year += 1900
+		} else {
+			pt.year += 2000
+		}
+	case 'm':
+		pt.month, rest, err = parseNumber(s, 2, 1, 12)
+	case 'b', 'h', 'B':
+		pt.month, rest, err = parseName(s, monthNames)
+		pt.month++
+	case 'd':
+		pt.day, rest, err = parseNumber(s, 2, 1, 31)
+	case 'e':
+		pt.day, rest, err = parseNumber(strings.TrimPrefix(s, " "), 2, 1, 31)
+	case 'j':
+		pt.yday, rest, err = parseNumber(s, 3, 1, 366)
+	case 'a', 'A':
+		// The weekday follows from the date, so it is only checked to be a weekday
+		_, rest, err = parseName(s, weekdayNames)
+	case 'H':
+		pt.hour, rest, err = parseNumber(s, 2, 0, 23)
+	case 'I':
+		pt.hour, rest, err = parseNumber(s, 2, 1, 12)
+	case 'M':
+		pt.min, rest, err = parseNumber(s, 2, 0, 59)
+	case 'S':
+		pt.sec, rest, err = parseNumber(s, 2, 0, 59)
+	case 'p':
+		switch {
+		case len(s) >= 2 && strings.EqualFold(s[:2], "AM"):
+			pt.pm = 1
+		case len(s) >= 2 && strings.EqualFold(s[:2], "PM"):
+			pt.pm = 2
+		default:
+			return s, errors.New("expected AM or PM")
+		}
+		rest = s[2:]
+	case 'z':
+		if s == "" || s[0] != '+' && s[0] != '-' {
+			return s, errors.New("expected a time zone offset")
+		}
+		var h, m int
+		if h, rest, err = parseNumber(s[1:], 2, 0, 23); err != nil {
+			return s, err
+		}
+		if m, rest, err = parseNumber(strings.TrimPrefix(rest, ":"), 2, 0, 59); err != nil {
+			return s, err
+		}
+		offset := h*3600 + m*60
+		if s[0] == '-' {
+			offset = -offset
+		}
+		pt.zone = time.FixedZone("", offset)
+	case 'Z':
+		i := 0
+		for i < len(s) && (s[i] >= 'A' && s[i] <= 'Z' || s[i] >= 'a' && s[i] <= 'z') {
+			i++
+		}
+		if i < 3 {
+			return s, errors.New("expected a time zone abbreviation")
+		}
+		pt.zoneName, rest = s[:i], s[i:]
+	}
+	return rest, err
+}
+
+// time returns the time given by the parsed values. Like in Go, the date defaults to January 1 of year 0,
+// and an unknown time zone abbreviation is taken to be UTC.
+func (pt *parsedTime) time(loc *time.Location) (time.Time, error) {
+	if pt.pm != 0 {
+		pt.hour %= 12
+		if pt.pm == 2 {
+			pt.hour += 12
+		}
+	}
+	month, day := time.Month(pt.month), pt.day
+	if month == 0 {
+		month = time.January
+	}
+	if day == 0 {
+		day = 1
+	}
+	if pt.yday > 0 {
+		t := time.Date(pt.year, time.January, pt.yday, 0, 0, 0, 0, time.UTC)
+		if t.Year() != pt.year || pt.month != 0 && t.Month() != month || pt.day != 0 && t.Day() != day {
+			return time.Time{}, fmt.Errorf("day %d of the year does not match the date", pt.yday)
+		}
+		month, day = t.Month(), t.Day()
+	}
+	if pt.zone != nil {
+		loc = pt.zone
+	}
+	t := time.Date(pt.year, month, day, pt.hour, pt.min, pt.sec, 0, loc)
+	if t.Day() != day {
+		return time.Time{}, fmt.Errorf("%s %d is not a valid date", month, day)
+	}
+	if pt.zoneName != "" && pt.zone == nil {
+		if name, _ := t.Zone(); name != pt.zoneName {
+			t = time.Date(pt.year, month, day, pt.hour, pt.min, pt.sec, 0, time.FixedZone(pt.zoneName, 0))
+		}
+	}
+	return t, nil
+}
+
+// Parse parses a string in the format. Literal text is matched directly, and the value of each directive is read
+// from the text that follows, so numbers don't need to be zero-padded.
+func (tf timeFormat) Parse(s string, loc *time.Location) (time.Time, error) {
+	var pt parsedTime
+	str := s
+	for _, f := range tf {
+		if f.literal {
+			if !strings.HasPrefix(str, f.text) {
+				return time.Time{}, fmt.Errorf("'%s' does not match the time format: expected '%s'", s, f.text)
+			}
+			str = str[len(f.text):]
+			continue
+		}
+		rest, err := pt.parseDirective(f.directive, str)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("'%s' does not match the time format at '%s': %%%c %w", s, str, f.directive, err)
+		}
+		str = rest
+	}
+	if len(str) > 0 {
+		return time.Time{}, fmt.Errorf("'%s' does not match the time format: extra text '%s'", s, str)
+	}
+	return pt.time(loc)
+}
+
+// newFormatConstructor parses the strftime format in the first const arg before creating the TimeBasic,
+// so that invalid formats give an error when the script is parsed
+func newFormatConstructor(f TimeBasicFunc) pipescript.TransformConstructor {
+	tb := NewTimeBasic(1, f)
+	return func(transform *pipescript.Transform, consts []interface{}, pipes []*pipescript.Pipe) (pipescript.TransformIterator, error) {
+		tf, err := parseTimeFormat(consts[0])
+		if err != nil {
+			return nil, err
+		}
+		return tb(transform, []interface{}{tf, consts[1]}, pipes)
+	}
+}
+
+var formatArg = pipescript.TransformArg{
+	Name:        "format",
+	Description: "The strftime-style format of the time (ex: '%Y-%m-%d %H:%M:%S')",
+	Type:        pipescript.ConstArgType,
+	Schema: map[string]interface{}{
+		"type": "string",
+	},
+}
+
+var Strftime = &pipescript.Transform{
+	Name:          "strftime",
+	Description:   "Returns the datapoint's timestamp formatted as a string",
+	Documentation: string(resources.MustAsset("docs/transforms/strftime.md")),
+	Args: []pipescript.TransformArg{
+		formatArg,
+		timezoneArg,
+	},
+	OutputSchema: map[string]interface{}{
+		"type": "string",
+	},
+	Constructor: newFormatConstructor(func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, tz *time.Location, st time.Time, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		out.Data = consts[0].(timeFormat).Format(dp.Time().In(tz))
+		return out, nil
+	}),
+}
+
+var Parsetime = &pipescript.Transform{
+	Name:        "parsetime",
+	Description: "Parses a string in the given format, returning the corresponding unix timestamp in seconds",
+	Args: []pipescript.TransformArg{
+		formatArg,
+		timezoneArg,
+	},
+	InputSchema: map[string]interface{}{
+		"type": "string",
+	},
+	OutputSchema: map[string]interface{}{
+		"type": "number",
+	},
+	Constructor: newFormatConstructor(func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, tz *time.Location, st time.Time, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		if dp.Data == nil {
+			out.Data = nil
+			return out, nil
+		}
+		s, ok := dp.Data.(string)
+		if !ok {
+			return nil, errors.New("parsetime can only parse strings")
+		}
+		// Times without a zone in the format are interpreted in the given time zone
+		t, err := consts[0].(timeFormat).Parse(s, tz)
+		if err != nil {
+			return nil, err
+		}
+		out.Data = float64(t.UnixNano()) / 1e9
+		return out, nil
+	}),
+}
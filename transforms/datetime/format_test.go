@@ -0,0 +1,124 @@
+package datetime
+
+import (
+	"testing"
+
+	"github.com/heedy/pipescript"
+)
+
+func TestStrftime(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "strftime('%q')",
+		Parsed:     "error",
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "strftime('%Y-%m-%d %H:%M', 'lol')",
+		Parsed:     "error",
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "strftime('%Y-%m-%d %H:%M', 'UTC')",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1577881800, Data: 1},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1577881800, Data: "2020-01-01 12:30"},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "strftime('%A %e %B, %I%p (100%%) on day %j', 'America/New_York')",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1577881800, Data: 1},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1577881800, Data: "Wednesday  1 January, 07AM (100%) on day 001"},
+		},
+	}.Run(t)
+}
+
+func TestParsetime(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "parsetime('%d/%m/%Y %T', 'UTC')",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "01/01/2020 12:30:00"},
+			{Timestamp: 2, Data: nil},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1577881800.0},
+			{Timestamp: 2, Data: nil},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "parsetime('%F %H:%M %z')",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "2020-01-01 07:30 -0500"},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1577881800.0},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "parsetime('%F', 'UTC')",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "January 1"},
+		},
+		OutputError: true,
+	}.Run(t)
+	// Literal text can contain characters that are part of Go time layouts
+	pipescript.TestCase{
+		Pipescript: "parsetime('Mon 1 Jan MST: %F at %I:%M %p', 'UTC')",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "Mon 1 Jan MST: 2020-01-01 at 12:30 PM"},
+			{Timestamp: 2, Data: "Mon 1 Jan MST: 2020-01-01 at 07:30 AM"},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1577881800.0},
+			{Timestamp: 2, Data: 1577863800.0},
+		},
+	}.Run(t)
+	// Numbers don't need to be zero-padded, and any text can separate the directives
+	pipescript.TestCase{
+		Pipescript: "parsetime('%d/%m/%Y %H:%M', 'UTC')",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "1/2/2024 3:04"},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1706756640.0},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "parsetime('%Y|%j', 'UTC')",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "2024|60"},
+			{Timestamp: 2, Data: "2024|1|"},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1709164800.0},
+		},
+		OutputError: true,
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "parsetime('%F', 'UTC')",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "2023-02-29"},
+		},
+		OutputError: true,
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "parsetime('%Y-%m-%d PM', 'UTC')",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "2020-01-01 AM"},
+		},
+		OutputError: true,
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "settime(d('date'):parsetime('%F', 'UTC'))",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"date": "2020-01-02"}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1577923200, Data: map[string]interface{}{"date": "2020-01-02"}},
+		},
+	}.Run(t)
+}
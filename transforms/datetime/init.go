@@ -53,6 +53,7 @@ func NewTimeBasic(tzarg int, f TimeBasicFunc) pipescript.TransformConstructor {
 }
 
 var timezoneArg = pipescript.TransformArg{
+	Name:        "timezone",
 	Description: "The time zone to use for determining timestamps, in IANA timezone database format (ex: 'America/New_York'). 'Local' uses the server time zone. 'UTC' uses UTC.",
 	Optional:    true,
 	Default:     pipescript.MustPipe(pipescript.NewConstTransform("Local"), nil),
@@ -73,19 +74,35 @@ func getTimezone(arg interface{}) (*time.Location, error) {
 func Register() {
 
 	Tshift.Register()
+	Settime.Register()
+	Setduration.Register()
+	Time.Register()
 
+	Strftime.Register()
+	Parsetime.Register()
+
+	Minute.Register()
 	Hour.Register()
 	Day.Register()
 	Week.Register()
 	Month.Register()
 	Year.Register()
 
+	Second.Register()
+	Hourminute.Register()
 	Dayhour.Register()
+	Timeofday.Register()
 
 	Weekday.Register()
 	Monthday.Register()
 	Yearday.Register()
 
 	Yearmonth.Register()
+	Quarter.Register()
+	Daysinmonth.Register()
+
+	Isoweek.Register()
+	Isoyear.Register()
+	Isweekend.Register()
 
 }
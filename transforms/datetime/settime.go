@@ -0,0 +1,122 @@
+package datetime
+
+import (
+	"errors"
+	"math"
+
+	"github.com/heedy/pipescript"
+	"github.com/heedy/pipescript/resources"
+)
+
+// timestampValue reads a unix timestamp in seconds from the data. RFC3339 strings are also accepted,
+// so that timestamps can be taken directly from fields such as "2020-01-01T00:00:00Z".
+func timestampValue(v interface{}) (float64, error) {
+	if s, ok := v.(string); ok {
+		return pipescript.ParseTimestamp(s)
+	}
+	f, ok := pipescript.FloatNoBool(v)
+	if !ok || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, errors.New("Timestamp must be a number of seconds since 1970, or an RFC3339 string")
+	}
+	return f, nil
+}
+
+type settimeIterator struct {
+	args     []*pipescript.Datapoint
+	duration bool
+}
+
+func (s *settimeIterator) OneToOne() bool {
+	return true
+}
+
+func (s *settimeIterator) Next(e *pipescript.TransformEnv, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+	dp, args, err := e.Next(s.args)
+	if err != nil || dp == nil {
+		return nil, err
+	}
+	out.Timestamp = dp.Timestamp
+	out.Duration = dp.Duration
+	out.Data = dp.Data
+
+	// A null value, such as a missing field, leaves the datapoint unchanged
+	if args[0].Data == nil {
+		return out, nil
+	}
+	if s.duration {
+		d, ok := pipescript.FloatNoBool(args[0].Data)
+		if !ok || d < 0 {
+			return nil, errors.New("setduration requires a non-negative number of seconds")
+		}
+		out.Duration = d
+		return out, nil
+	}
+	out.Timestamp, err = timestampValue(args[0].Data)
+	return out, err
+}
+
+var Settime = &pipescript.Transform{
+	Name:          "settime",
+	Description:   "Sets the datapoint's timestamp to the value of its argument, keeping the data unchanged",
+	Documentation: string(resources.MustAsset("docs/transforms/settime.md")),
+	Args: []pipescript.TransformArg{
+		{
+			Description: "The new timestamp, either as a unix timestamp in seconds, or as an RFC3339 string",
+			Type:        pipescript.TransformArgType,
+			Schema: map[string]interface{}{
+				"type": []string{"number", "string"},
+			},
+		},
+	},
+	Constructor: func(transform *pipescript.Transform, consts []interface{}, pipes []*pipescript.Pipe) (pipescript.TransformIterator, error) {
+		return &settimeIterator{args: make([]*pipescript.Datapoint, 1)}, nil
+	},
+}
+
+var Setduration = &pipescript.Transform{
+	Name:        "setduration",
+	Description: "Sets the datapoint's duration to the number of seconds given in its argument, keeping the data unchanged",
+	Args: []pipescript.TransformArg{
+		{
+			Description: "The new duration in seconds",
+			Type:        pipescript.TransformArgType,
+			Schema: map[string]interface{}{
+				"type":    "number",
+				"minimum": 0,
+			},
+		},
+	},
+	Constructor: func(transform *pipescript.Transform, consts []interface{}, pipes []*pipescript.Pipe) (pipescript.TransformIterator, error) {
+		return &settimeIterator{args: make([]*pipescript.Datapoint, 1), duration: true}, nil
+	},
+}
+
+var Time = &pipescript.Transform{
+	Name:        "time",
+	Description: "Returns the unix timestamp in seconds of the RFC3339 time or date given in its argument (ex: time('2024-01-01')). Times without a time zone are in UTC.",
+	Args: []pipescript.TransformArg{
+		{
+			Description: "The time to convert, such as '2024-01-01T00:00:00Z' or '2024-01-01'",
+			Type:        pipescript.TransformArgType,
+			Schema: map[string]interface{}{
+				"type": "string",
+			},
+		},
+	},
+	OutputSchema: map[string]interface{}{
+		"type": "number",
+	},
+	Constructor: pipescript.NewArgBasic(func(args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		if args[0].Data == nil {
+			out.Data = nil
+			return out, nil
+		}
+		s, ok := args[0].Data.(string)
+		if !ok {
+			return nil, errors.New("time requires a string argument")
+		}
+		ts, err := pipescript.ParseTimestamp(s)
+		out.Data = ts
+		return out, err
+	}),
+}
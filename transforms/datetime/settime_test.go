@@ -0,0 +1,91 @@
+package datetime
+
+import (
+	"testing"
+
+	"github.com/heedy/pipescript"
+)
+
+func TestSettime(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "settime(d('measured'))",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"measured": 1577836800.0}},
+			{Timestamp: 2, Data: map[string]interface{}{"measured": "2020-01-01T00:01:00Z"}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1577836800, Data: map[string]interface{}{"measured": 1577836800.0}},
+			{Timestamp: 1577836860, Data: map[string]interface{}{"measured": "2020-01-01T00:01:00Z"}},
+		},
+	}.Run(t)
+
+	// Datapoints without a time are passed through unchanged
+	pipescript.TestCase{
+		Pipescript: "settime(d('measured'))",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"measured": nil}},
+			{Timestamp: 2, Data: map[string]interface{}{"value": 1}},
+			{Timestamp: 3, Data: map[string]interface{}{"measured": 10.0}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"measured": nil}},
+			{Timestamp: 2, Data: map[string]interface{}{"value": 1}},
+			{Timestamp: 10, Data: map[string]interface{}{"measured": 10.0}},
+		},
+	}.Run(t)
+
+	pipescript.TestCase{
+		Pipescript: "settime(d('measured'))",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"measured": "yesterday"}},
+		},
+		OutputError: true,
+	}.Run(t)
+
+	pipescript.TestCase{
+		Pipescript: "setduration(d - 1)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 3.0},
+			{Timestamp: 2, Data: 11.0},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Duration: 2, Data: 3.0},
+			{Timestamp: 2, Duration: 10, Data: 11.0},
+		},
+	}.Run(t)
+
+	pipescript.TestCase{
+		Pipescript: "setduration(-1)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 3.0},
+		},
+		OutputError: true,
+	}.Run(t)
+}
+
+func TestTime(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "time('2024-01-01')",
+		Parsed:     "1704067200",
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "time('yesterday')",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1},
+		},
+		OutputError: true,
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "t > time(d)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1709251200, Data: "2024-03-02"},
+			{Timestamp: 1709251201, Data: "2024-03-01"},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1709251200, Data: false},
+			{Timestamp: 1709251201, Data: true},
+		},
+	}.Run(t)
+}
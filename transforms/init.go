@@ -6,10 +6,13 @@ This package imports EVERYTHING
 package transforms
 
 import (
+	"github.com/heedy/pipescript/datasets/interpolators" // Interpolators used by asof
+	"github.com/heedy/pipescript/transforms/arrays"   // Array manipulation
 	"github.com/heedy/pipescript/transforms/core"     // The core transforms
 	"github.com/heedy/pipescript/transforms/datetime" // Manipulating timestamps
 	"github.com/heedy/pipescript/transforms/misc"     // Miscellaneous transforms
 	"github.com/heedy/pipescript/transforms/numeric"  // Statistical transforms
+	"github.com/heedy/pipescript/transforms/objects"  // Object manipulation
 	"github.com/heedy/pipescript/transforms/strings"  // Text-based transforms
 )
 
@@ -20,4 +23,8 @@ func Register() {
 	datetime.Register()
 	strings.Register()
 	misc.Register()
+	arrays.Register()
+	objects.Register()
+
+	interpolators.Register()
 }
@@ -0,0 +1,91 @@
+package misc
+
+import (
+	"errors"
+
+	"github.com/heedy/pipescript"
+	"github.com/heedy/pipescript/datasets"
+	"github.com/heedy/pipescript/resources"
+)
+
+type asofIter struct {
+	stream       *pipescript.Pipe
+	interpolator string
+
+	ref  *pipescript.BufferIterator
+	iter pipescript.Iterator
+	in   pipescript.Datapoint
+}
+
+func (a *asofIter) OneToOne() bool {
+	return true
+}
+
+func (a *asofIter) Next(e *pipescript.TransformEnv, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+	if a.iter == nil {
+		// The interpolator reads the input datapoints as its reference, so the input is buffered
+		// to also get each datapoint's timestamp here
+		b := pipescript.NewBuffer(pipescript.IteratorFromBI{BI: e.Iter})
+		a.ref = b.Iterator()
+		a.stream.InputIterator(pipescript.EmptyIterator{})
+		it, err := datasets.GetInterpolator(a.interpolator, nil, b.Iterator(), a.stream)
+		if err != nil {
+			return nil, err
+		}
+		a.iter = it
+	}
+	dp, err := a.ref.Next()
+	if err != nil || dp == nil {
+		return nil, err
+	}
+	out.Timestamp = dp.Timestamp
+	out.Duration = dp.Duration
+	v, err := a.iter.Next(&a.in)
+	if err != nil {
+		return nil, err
+	}
+	out.Data = nil
+	if v != nil {
+		out.Data = v.Data
+	}
+	return out, nil
+}
+
+var Asof = &pipescript.Transform{
+	Name:          "asof",
+	Description:   "Returns the value of a named input stream at the timestamp of each datapoint",
+	Documentation: string(resources.MustAsset("docs/transforms/asof.md")),
+	Args: []pipescript.TransformArg{
+		{
+			Name:        "stream",
+			Description: "The named input stream to interpolate, such as $heartrate. Transforms can follow it, as in $heartrate:d('bpm').",
+			Type:        pipescript.PipeArgType,
+		},
+		{
+			Name:        "interpolator",
+			Description: "The interpolator to use, or a transform that is run on the stream's datapoints since the previous datapoint",
+			Type:        pipescript.ConstArgType,
+			Optional:    true,
+			Schema: map[string]interface{}{
+				"type":    "string",
+				"default": "closest",
+			},
+		},
+	},
+	Constructor: func(transform *pipescript.Transform, consts []interface{}, pipes []*pipescript.Pipe) (pipescript.TransformIterator, error) {
+		if len(pipes[0].Streams()) == 0 {
+			return nil, errors.New("The first argument of asof must be a named input stream, such as $heartrate")
+		}
+		interpolator, ok := consts[0].(string)
+		if !ok {
+			return nil, errors.New("The interpolator of asof must be a string")
+		}
+		if err := datasets.CheckInterpolator(interpolator); err != nil {
+			return nil, err
+		}
+		return &asofIter{
+			stream:       pipes[0],
+			interpolator: interpolator,
+		}, nil
+	},
+}
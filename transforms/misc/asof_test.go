@@ -0,0 +1,85 @@
+package misc
+
+import (
+	"testing"
+
+	"github.com/heedy/pipescript"
+	"github.com/heedy/pipescript/datasets/interpolators"
+	"github.com/heedy/pipescript/transforms/numeric"
+)
+
+func TestAsof(t *testing.T) {
+	Asof.Register()
+	interpolators.Register()
+	numeric.Register()
+
+	heartrate := map[string][]pipescript.Datapoint{
+		"heartrate": {
+			{Timestamp: 0, Data: map[string]interface{}{"bpm": 70}},
+			{Timestamp: 4, Data: map[string]interface{}{"bpm": 85}},
+			{Timestamp: 9, Data: map[string]interface{}{"bpm": 90}},
+		},
+	}
+	steps := []pipescript.Datapoint{
+		{Timestamp: 1, Data: 10},
+		{Timestamp: 5, Data: 12},
+		{Timestamp: 8, Data: 3},
+	}
+
+	pipescript.TestCase{
+		Pipescript: "asof($heartrate:d('bpm'))",
+		Parsed:     `asof($heartrate:d("bpm"),"closest")`,
+		Input:      steps,
+		Streams:    heartrate,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 70},
+			{Timestamp: 5, Data: 85},
+			{Timestamp: 8, Data: 90},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "{'steps': d, 'hr': asof($heartrate, 'closest'):d('bpm')}",
+		Input:      steps[:1],
+		Streams:    heartrate,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"steps": 10, "hr": 70}},
+		},
+	}.Run(t)
+
+	// Interpolating with a transform
+	pipescript.TestCase{
+		Pipescript: "asof($heartrate:d('bpm'), 'count')",
+		Input:      steps,
+		Streams:    heartrate,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: int64(1)},
+			{Timestamp: 5, Data: int64(1)},
+			{Timestamp: 8, Data: int64(0)},
+		},
+	}.Run(t)
+
+	// An empty stream gives null
+	pipescript.TestCase{
+		Pipescript: "asof($heartrate)",
+		Input:      steps[:1],
+		Streams:    map[string][]pipescript.Datapoint{"heartrate": {}},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: nil},
+		},
+	}.Run(t)
+
+	pipescript.TestCase{
+		Pipescript: "asof(d)",
+		Parsed:     "error",
+	}.Run(t)
+	// Unknown interpolators are found when the script is parsed
+	pipescript.TestCase{
+		Pipescript: "asof($heartrate, 'nosuch')",
+		Parsed:     "error",
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript:  "asof($heartrate)",
+		Input:       steps,
+		OutputError: true,
+	}.Run(t)
+}
@@ -0,0 +1,466 @@
+package misc
+
+import (
+	"errors"
+	"math"
+
+	"github.com/heedy/pipescript"
+	"github.com/heedy/pipescript/resources"
+)
+
+// locationSchema is the schema of datapoints with a location, which have latitude and longitude keys in decimal degrees
+var locationSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"latitude": map[string]interface{}{
+			"type": "number",
+		},
+		"longitude": map[string]interface{}{
+			"type": "number",
+		},
+	},
+	"required": []string{"latitude", "longitude"},
+}
+
+// location returns the latitude and longitude of a datapoint's data, in degrees
+func location(v interface{}) (float64, float64, error) {
+	data, ok := v.(map[string]interface{})
+	if !ok {
+		return 0, 0, errors.New("Location must be an object with latitude and longitude keys")
+	}
+	v, ok = data["latitude"]
+	if !ok {
+		return 0, 0, errors.New("Could not find latitude in datapoint")
+	}
+	lat, ok := pipescript.FloatNoBool(v)
+	if !ok {
+		return 0, 0, errors.New("Latitude must be a number")
+	}
+	v, ok = data["longitude"]
+	if !ok {
+		return 0, 0, errors.New("Could not find longitude in datapoint")
+	}
+	long, ok := pipescript.FloatNoBool(v)
+	if !ok {
+		return 0, 0, errors.New("Longitude must be a number")
+	}
+	return lat, long, nil
+}
+
+// haversine returns the distance in meters between two coordinates, using the haversine formula
+func haversine(lat1, long1, lat2, long2 float64) float64 {
+	lat1, long1, lat2, long2 = lat1*Radians, long1*Radians, lat2*Radians, long2*Radians
+	dlat := lat2 - lat1
+	dlong := long2 - long1
+	a := math.Sin(dlat/2)*math.Sin(dlat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dlong/2)*math.Sin(dlong/2)
+	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+	return EarthRadius * c
+}
+
+// bearing returns the initial compass bearing in degrees from the first to the second coordinate
+func bearing(lat1, long1, lat2, long2 float64) float64 {
+	lat1, long1, lat2, long2 = lat1*Radians, long1*Radians, lat2*Radians, long2*Radians
+	dlong := long2 - long1
+	y := math.Sin(dlong) * math.Cos(lat2)
+	x := math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(dlong)
+	return math.Mod(math.Atan2(y, x)/Radians+360, 360)
+}
+
+// consecutiveIter returns a value computed from each location and the location of the previous datapoint.
+// The first datapoint and null data give null.
+type consecutiveIter struct {
+	f func(lat1, long1, lat2, long2, dt float64) interface{}
+
+	started   bool
+	lat, long float64
+	ts        float64
+}
+
+func (c *consecutiveIter) OneToOne() bool {
+	return true
+}
+
+func (c *consecutiveIter) Next(e *pipescript.TransformEnv, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+	dp, _, err := e.Next(nil)
+	if err != nil || dp == nil {
+		return nil, err
+	}
+	out.Timestamp = dp.Timestamp
+	out.Duration = dp.Duration
+	out.Data = nil
+	if dp.Data == nil {
+		return out, nil
+	}
+	lat, long, err := location(dp.Data)
+	if err != nil {
+		return nil, err
+	}
+	if c.started {
+		out.Data = c.f(c.lat, c.long, lat, long, dp.Timestamp-c.ts)
+	}
+	c.started = true
+	c.lat, c.long, c.ts = lat, long, dp.Timestamp
+	return out, nil
+}
+
+var Speed = &pipescript.Transform{
+	Name:          "speed",
+	Description:   "Returns the speed in meters per second from the previous location to each location",
+	Documentation: string(resources.MustAsset("docs/transforms/speed.md")),
+	InputSchema:   locationSchema,
+	OutputSchema: map[string]interface{}{
+		"type": "number",
+	},
+	Constructor: func(transform *pipescript.Transform, consts []interface{}, pipes []*pipescript.Pipe) (pipescript.TransformIterator, error) {
+		return &consecutiveIter{f: func(lat1, long1, lat2, long2, dt float64) interface{} {
+			if dt <= 0 {
+				return nil
+			}
+			return haversine(lat1, long1, lat2, long2) / dt
+		}}, nil
+	},
+}
+
+var Bearing = &pipescript.Transform{
+	Name:          "bearing",
+	Description:   "Returns the compass direction in degrees from the previous location to each location",
+	Documentation: string(resources.MustAsset("docs/transforms/bearing.md")),
+	InputSchema:   locationSchema,
+	OutputSchema: map[string]interface{}{
+		"type": "number",
+	},
+	Constructor: func(transform *pipescript.Transform, consts []interface{}, pipes []*pipescript.Pipe) (pipescript.TransformIterator, error) {
+		return &consecutiveIter{f: func(lat1, long1, lat2, long2, dt float64) interface{} {
+			if lat1 == lat2 && long1 == long2 {
+				// There is no direction without movement
+				return nil
+			}
+			return bearing(lat1, long1, lat2, long2)
+		}}, nil
+	},
+}
+
+var Pathlength = &pipescript.Transform{
+	Name:          "pathlength",
+	Description:   "Returns the total distance in meters traveled between the locations",
+	Documentation: string(resources.MustAsset("docs/transforms/pathlength.md")),
+	InputSchema:   locationSchema,
+	OutputSchema: map[string]interface{}{
+		"type": "number",
+	},
+	Constructor: pipescript.NewAggregator(func(e *pipescript.TransformEnv, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		dp, _, err := e.Next(nil)
+		if err != nil || dp == nil {
+			return nil, err
+		}
+		out.Timestamp = dp.Timestamp
+		total := float64(0)
+		started := false
+		var plat, plong float64
+		for dp != nil {
+			// Null values are skipped
+			if dp.Data != nil {
+				lat, long, err := location(dp.Data)
+				if err != nil {
+					return nil, err
+				}
+				if started {
+					total += haversine(plat, plong, lat, long)
+				}
+				plat, plong, started = lat, long, true
+			}
+			out.Duration = dp.Timestamp + dp.Duration - out.Timestamp
+			dp, _, err = e.Next(nil)
+			if err != nil {
+				return nil, err
+			}
+		}
+		out.Data = total
+		return out, nil
+	}),
+}
+
+var InCircle = &pipescript.Transform{
+	Name:          "incircle",
+	Description:   "Returns true if the location is within the given radius in meters of a latitude/longitude coordinate",
+	Documentation: string(resources.MustAsset("docs/transforms/incircle.md")),
+	Args: []pipescript.TransformArg{
+		{
+			Name:        "latitude",
+			Description: "Latitude of the center",
+			Type:        pipescript.ConstArgType,
+			Schema: map[string]interface{}{
+				"type": "number",
+			},
+		},
+		{
+			Name:        "longitude",
+			Description: "Longitude of the center",
+			Type:        pipescript.ConstArgType,
+			Schema: map[string]interface{}{
+				"type": "number",
+			},
+		},
+		{
+			Name:        "radius",
+			Description: "Radius of the circle in meters",
+			Type:        pipescript.ConstArgType,
+			Schema: map[string]interface{}{
+				"type":    "number",
+				"minimum": 0,
+			},
+		},
+	},
+	InputSchema: locationSchema,
+	OutputSchema: map[string]interface{}{
+		"type": "boolean",
+	},
+	Constructor: pipescript.NewBasic(nil, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		if dp.Data == nil {
+			out.Data = nil
+			return out, nil
+		}
+		lat, long, err := location(dp.Data)
+		if err != nil {
+			return nil, err
+		}
+		out.Data = haversine(consts[0].(float64), consts[1].(float64), lat, long) <= consts[2].(float64)
+		return out, nil
+	}),
+}
+
+var InGeofence = &pipescript.Transform{
+	Name:          "ingeofence",
+	Description:   "Returns true if the location is inside of the polygon given by an array of [latitude, longitude] points",
+	Documentation: string(resources.MustAsset("docs/transforms/ingeofence.md")),
+	Args: []pipescript.TransformArg{
+		{
+			Name:        "polygon",
+			Description: "The corners of the geofence, as an array of [latitude, longitude] points",
+			Type:        pipescript.ConstArgType,
+			Schema: map[string]interface{}{
+				"type":     "array",
+				"minItems": 3,
+				"items": map[string]interface{}{
+					"type":     "array",
+					"minItems": 2,
+					"maxItems": 2,
+					"items": map[string]interface{}{
+						"type": "number",
+					},
+				},
+			},
+		},
+	},
+	InputSchema: locationSchema,
+	OutputSchema: map[string]interface{}{
+		"type": "boolean",
+	},
+	Constructor: pipescript.NewBasic(func(consts []interface{}, pipes []*pipescript.Pipe) ([]interface{}, []*pipescript.Pipe, error) {
+		pts := consts[0].([]interface{})
+		poly := make([][2]float64, len(pts))
+		for i := range pts {
+			pt := pts[i].([]interface{})
+			poly[i][0], _ = pipescript.Float(pt[0])
+			poly[i][1], _ = pipescript.Float(pt[1])
+		}
+		return []interface{}{poly}, pipes, nil
+	}, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		if dp.Data == nil {
+			out.Data = nil
+			return out, nil
+		}
+		lat, long, err := location(dp.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		// Count the edges that a line going east from the location crosses. The polygon's edges are treated
+		// as straight lines in latitude/longitude, which is accurate for small areas.
+		poly := consts[0].([][2]float64)
+		inside := false
+		for i, j := 0, len(poly)-1; i < len(poly); j, i = i, i+1 {
+			if (poly[i][0] > lat) != (poly[j][0] > lat) &&
+				long < poly[j][1]+(lat-poly[j][0])*(poly[i][1]-poly[j][1])/(poly[i][0]-poly[j][0]) {
+				inside = !inside
+			}
+		}
+		out.Data = inside
+		return out, nil
+	}),
+}
+
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// geohash encodes the latitude and longitude in degrees as a geohash with the given number of characters
+func geohash(lat, long float64, precision int) string {
+	latr := [2]float64{-90, 90}
+	longr := [2]float64{-180, 180}
+	res := make([]byte, precision)
+	even := true
+	for i := range res {
+		c := 0
+		for b := 0; b < 5; b++ {
+			// Bits alternate between longitude and latitude, starting with longitude
+			r, v := &latr, lat
+			if even {
+				r, v = &longr, long
+			}
+			mid := (r[0] + r[1]) / 2
+			c <<= 1
+			if v >= mid {
+				c |= 1
+				r[0] = mid
+			} else {
+				r[1] = mid
+			}
+			even = !even
+		}
+		res[i] = geohashAlphabet[c]
+	}
+	return string(res)
+}
+
+var Geohash = &pipescript.Transform{
+	Name:          "geohash",
+	Description:   "Encodes the location as a geohash string, where nearby locations share a prefix",
+	Documentation: string(resources.MustAsset("docs/transforms/geohash.md")),
+	Args: []pipescript.TransformArg{
+		{
+			Name:        "precision",
+			Description: "The number of characters of the geohash. Each character makes the cell about 6 times smaller.",
+			Type:        pipescript.ConstArgType,
+			Optional:    true,
+			Schema: map[string]interface{}{
+				"type":    "integer",
+				"minimum": 1,
+				"maximum": 12,
+				"default": 9,
+			},
+		},
+	},
+	InputSchema: locationSchema,
+	OutputSchema: map[string]interface{}{
+		"type": "string",
+	},
+	Constructor: pipescript.NewBasic(nil, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		if dp.Data == nil {
+			out.Data = nil
+			return out, nil
+		}
+		lat, long, err := location(dp.Data)
+		if err != nil {
+			return nil, err
+		}
+		out.Data = geohash(lat, long, int(consts[0].(int64)))
+		return out, nil
+	}),
+}
+
+// newLocationAggregator returns an aggregator which passes the location of each datapoint to add,
+// and returns the result of the function when done. Null values are skipped, and if there are no locations,
+// the result is null.
+func newLocationAggregator(add func(lat, long float64), result func() interface{}) pipescript.AggregatorFunc {
+	return func(e *pipescript.TransformEnv, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		dp, _, err := e.Next(nil)
+		if err != nil || dp == nil {
+			return nil, err
+		}
+		out.Timestamp = dp.Timestamp
+		count := 0
+		for dp != nil {
+			if dp.Data != nil {
+				lat, long, err := location(dp.Data)
+				if err != nil {
+					return nil, err
+				}
+				add(lat, long)
+				count++
+			}
+			out.Duration = dp.Timestamp + dp.Duration - out.Timestamp
+			dp, _, err = e.Next(nil)
+			if err != nil {
+				return nil, err
+			}
+		}
+		out.Data = nil
+		if count > 0 {
+			out.Data = result()
+		}
+		return out, nil
+	}
+}
+
+// centroid sums locations as points in 3D, which averages them correctly across the poles and the 180th meridian
+type centroid struct {
+	x, y, z float64
+}
+
+func (c *centroid) add(lat, long float64) {
+	lat, long = lat*Radians, long*Radians
+	c.x += math.Cos(lat) * math.Cos(long)
+	c.y += math.Cos(lat) * math.Sin(long)
+	c.z += math.Sin(lat)
+}
+
+// location returns the average of the added locations as an object with latitude and longitude
+func (c *centroid) location() map[string]interface{} {
+	return map[string]interface{}{
+		"latitude":  math.Atan2(c.z, math.Sqrt(c.x*c.x+c.y*c.y)) / Radians,
+		"longitude": math.Atan2(c.y, c.x) / Radians,
+	}
+}
+
+var Centroid = &pipescript.Transform{
+	Name:          "centroid",
+	Description:   "Returns the average location of the datapoints",
+	Documentation: string(resources.MustAsset("docs/transforms/centroid.md")),
+	InputSchema:   locationSchema,
+	OutputSchema:  locationSchema,
+	Constructor: func(transform *pipescript.Transform, consts []interface{}, pipes []*pipescript.Pipe) (pipescript.TransformIterator, error) {
+		var c centroid
+		return pipescript.NewAggregator(newLocationAggregator(c.add, func() interface{} {
+			return c.location()
+		}))(transform, consts, pipes)
+	},
+}
+
+var Bbox = &pipescript.Transform{
+	Name:          "bbox",
+	Description:   "Returns the minimum and maximum latitude and longitude of the locations",
+	Documentation: string(resources.MustAsset("docs/transforms/bbox.md")),
+	InputSchema:   locationSchema,
+	OutputSchema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"min_latitude": map[string]interface{}{
+				"type": "number",
+			},
+			"max_latitude": map[string]interface{}{
+				"type": "number",
+			},
+			"min_longitude": map[string]interface{}{
+				"type": "number",
+			},
+			"max_longitude": map[string]interface{}{
+				"type": "number",
+			},
+		},
+	},
+	Constructor: func(transform *pipescript.Transform, consts []interface{}, pipes []*pipescript.Pipe) (pipescript.TransformIterator, error) {
+		minlat, minlong := math.Inf(1), math.Inf(1)
+		maxlat, maxlong := math.Inf(-1), math.Inf(-1)
+		return pipescript.NewAggregator(newLocationAggregator(func(lat, long float64) {
+			minlat, maxlat = math.Min(minlat, lat), math.Max(maxlat, lat)
+			minlong, maxlong = math.Min(minlong, long), math.Max(maxlong, long)
+		}, func() interface{} {
+			return map[string]interface{}{
+				"min_latitude":  minlat,
+				"max_latitude":  maxlat,
+				"min_longitude": minlong,
+				"max_longitude": maxlong,
+			}
+		}))(transform, consts, pipes)
+	},
+}
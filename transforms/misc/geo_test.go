@@ -0,0 +1,150 @@
+package misc
+
+import (
+	"testing"
+
+	"github.com/heedy/pipescript"
+)
+
+var walk = []pipescript.Datapoint{
+	{Timestamp: 0, Data: map[string]interface{}{"latitude": 40.4243, "longitude": -86.9114}},
+	{Timestamp: 60, Data: map[string]interface{}{"latitude": 40.4263, "longitude": -86.9114}},
+	{Timestamp: 120, Data: map[string]interface{}{"latitude": 40.4263, "longitude": -86.9084}},
+	{Timestamp: 180, Data: nil},
+}
+
+func TestSpeed(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "speed",
+		Input:      walk,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 0, Data: nil},
+			{Timestamp: 60, Data: 3.706497554808963},
+			{Timestamp: 120, Data: 4.232305335732483},
+			{Timestamp: 180, Data: nil},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "speed",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "hi"},
+		},
+		OutputError: true,
+	}.Run(t)
+}
+
+func TestBearing(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "bearing",
+		Input:      walk,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 0, Data: nil},
+			{Timestamp: 60, Data: float64(0)},
+			{Timestamp: 120, Data: 89.99902729592907},
+			{Timestamp: 180, Data: nil},
+		},
+	}.Run(t)
+}
+
+func TestPathlength(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "pathlength",
+		Input:      walk,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 0, Duration: 180, Data: 476.3281734324868},
+		},
+	}.Run(t)
+}
+
+func TestInCircle(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "incircle(40.4263, -86.91, 200)",
+		Input:      walk,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 0, Data: false},
+			{Timestamp: 60, Data: true},
+			{Timestamp: 120, Data: true},
+			{Timestamp: 180, Data: nil},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "incircle(40.4263, -86.91, -1)",
+		Parsed:     "error",
+	}.Run(t)
+}
+
+func TestInGeofence(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "ingeofence([[40.425,-86.912],[40.427,-86.912],[40.427,-86.905],[40.425,-86.905]])",
+		Input:      walk,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 0, Data: false},
+			{Timestamp: 60, Data: true},
+			{Timestamp: 120, Data: true},
+			{Timestamp: 180, Data: nil},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "ingeofence([[40.425,-86.912],[40.427,-86.912]])",
+		Parsed:     "error",
+	}.Run(t)
+}
+
+func TestGeohash(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "geohash",
+		Input:      walk,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 0, Data: "dp4jyr109"},
+			{Timestamp: 60, Data: "dp4jyr3h3"},
+			{Timestamp: 120, Data: "dp4jyr7hr"},
+			{Timestamp: 180, Data: nil},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "geohash(11)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"latitude": 57.64911, "longitude": 10.40744}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "u4pruydqqvj"},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "geohash(13)",
+		Parsed:     "error",
+	}.Run(t)
+}
+
+func TestCentroid(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "centroid",
+		Input:      walk,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 0, Duration: 180, Data: map[string]interface{}{"latitude": 40.42563334194895, "longitude": -86.91040000991161}},
+		},
+	}.Run(t)
+}
+
+func TestBbox(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "bbox",
+		Input:      walk,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 0, Duration: 180, Data: map[string]interface{}{
+				"min_latitude":  40.4243,
+				"max_latitude":  40.4263,
+				"min_longitude": -86.9114,
+				"max_longitude": -86.9084,
+			}},
+		},
+	}.Run(t)
+}
@@ -7,5 +7,17 @@ func Register() {
 	First.Register()
 	Last.Register()
 	Distance.Register()
+	Speed.Register()
+	Bearing.Register()
+	Pathlength.Register()
+	InCircle.Register()
+	InGeofence.Register()
+	Geohash.Register()
+	Centroid.Register()
+	Bbox.Register()
+	Staypoints.Register()
+	Trips.Register()
 	Length.Register()
+	Asof.Register()
+	Pattern.Register()
 }
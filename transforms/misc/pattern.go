@@ -0,0 +1,327 @@
+package misc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/heedy/pipescript"
+	"github.com/heedy/pipescript/resources"
+)
+
+// patternRun is a partial match of the pattern
+type patternRun struct {
+	start  float64       // The timestamp of the first matched datapoint
+	last   float64       // The timestamp of the most recently matched datapoint
+	values []interface{} // The data of the matched datapoints
+}
+
+// heldMatch is a complete match that is returned once the timeout after its last step passes,
+// unless the absent condition of the last step is true for a datapoint before then
+type heldMatch struct {
+	deadline float64
+	dp       pipescript.Datapoint
+}
+
+type patternIter struct {
+	within  float64
+	gap     float64   // The maximum time between any step and the next one, 0 having no limit
+	gaps    []float64 // The maximum time after each step, if given as an array
+	timeout float64   // The time after the last step during which its absent condition is checked
+
+	steps  *pipescript.Pipe
+	absent *pipescript.Pipe
+
+	// The input is buffered, so that the steps and absent conditions can be computed for each datapoint
+	ref *pipescript.BufferIterator
+
+	// runs[k] is the partial match which has matched k+1 steps. There is at most one for each step,
+	// keeping the one that started last, which gives the shortest match.
+	runs []*patternRun
+	held []heldMatch
+	out  []pipescript.Datapoint
+	done bool
+}
+
+func (p *patternIter) OneToOne() bool {
+	return false
+}
+
+// expired returns true if the run can no longer be continued by a datapoint at the given timestamp
+func (p *patternIter) expired(r *patternRun, k int, ts float64) bool {
+	if p.within > 0 && ts-r.start > p.within {
+		return true
+	}
+	gap := p.gap
+	if p.gaps != nil {
+		gap = p.gaps[k]
+	}
+	return gap > 0 && ts-r.last > gap
+}
+
+// checkSteps returns an error if the gaps or absent conditions don't match the number of steps
+func (p *patternIter) checkSteps(nsteps int, nabsent int) error {
+	if nsteps == 0 {
+		return errors.New("pattern: there must be at least one step")
+	}
+	if p.gaps != nil && len(p.gaps) != nsteps-1 {
+		return fmt.Errorf("pattern: %d gaps were given for %d steps, but there must be one less gap than steps", len(p.gaps), nsteps)
+	}
+	if nabsent >= 0 && nabsent != nsteps {
+		return fmt.Errorf("pattern: %d absent conditions were given for %d steps, but there must be one for each step", nabsent, nsteps)
+	}
+	return nil
+}
+
+// arrayLength returns the number of elements of a pipe that is an array literal, such as [d==1, d==2]
+func arrayLength(p *pipescript.Pipe) (int, bool) {
+	if v, err := p.GetConst(); err == nil {
+		arr, ok := v.([]interface{})
+		return len(arr), ok
+	}
+	if len(p.Arr) == 1 && p.Arr[0].Transform == pipescript.ArrayTransform {
+		return len(p.Arr[0].Args), true
+	}
+	return 0, false
+}
+
+// conditions converts the value of the steps or absent conditions to booleans
+func conditions(v interface{}, name string) ([]bool, error) {
+	arr, ok := v.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("pattern: the %s must be an array of conditions, such as [d==1, d==2]", name)
+	}
+	res := make([]bool, len(arr))
+	for i, c := range arr {
+		b, ok := pipescript.Bool(c)
+		if !ok && c != nil {
+			return nil, fmt.Errorf("pattern: %s %d is not a boolean", name, i)
+		}
+		res[i] = b
+	}
+	return res, nil
+}
+
+// add processes the next datapoint, with the results of the steps and absent conditions for it
+func (p *patternIter) add(dp *pipescript.Datapoint, sv *pipescript.Datapoint, av *pipescript.Datapoint) error {
+	matched, err := conditions(sv.Data, "steps")
+	if err != nil {
+		return err
+	}
+	// The absent condition is either a single boolean for the whole match, or an array with one for each step
+	var absent []bool
+	absentAll, isBool := pipescript.Bool(av.Data)
+	if !isBool && av.Data != nil {
+		if absent, err = conditions(av.Data, "absent conditions"); err != nil {
+			return err
+		}
+	}
+	if p.runs == nil {
+		nabsent := -1
+		if absent != nil {
+			nabsent = len(absent)
+		}
+		if err = p.checkSteps(len(matched), nabsent); err != nil {
+			return err
+		}
+		p.runs = make([]*patternRun, len(matched))
+	} else if len(matched) != len(p.runs) || absent != nil && len(absent) != len(p.runs) {
+		return errors.New("pattern: the number of steps changed")
+	}
+
+	// Held matches whose timeout passed are returned, and the rest are dropped if the datapoint must not follow them
+	for len(p.held) > 0 && dp.Timestamp > p.held[0].deadline {
+		p.out = append(p.out, p.held[0].dp)
+		p.held = p.held[1:]
+	}
+	if absentAll || absent != nil && absent[len(absent)-1] {
+		p.held = nil
+	}
+
+	for k, r := range p.runs {
+		// A datapoint that must not happen after step k cancels the partial match waiting for the next step
+		if r != nil && (absentAll || absent != nil && absent[k] || p.expired(r, k, dp.Timestamp)) {
+			p.runs[k] = nil
+		}
+	}
+
+	// Go from the longest partial match to the shortest, so that the datapoint is only used once in each match
+	for k := len(p.runs) - 1; k >= 0; k-- {
+		if !matched[k] {
+			continue
+		}
+		var r *patternRun
+		if k == 0 {
+			r = &patternRun{
+				start:  dp.Timestamp,
+				values: []interface{}{dp.Data},
+			}
+		} else if prev := p.runs[k-1]; prev != nil {
+			r = &patternRun{
+				start:  prev.start,
+				values: append(append(make([]interface{}, 0, k+1), prev.values...), dp.Data),
+			}
+		} else {
+			continue
+		}
+		r.last = dp.Timestamp
+
+		if k == len(p.runs)-1 {
+			// The pattern is complete. Matches don't overlap, so all partial matches are removed.
+			for i := range p.runs {
+				p.runs[i] = nil
+			}
+			m := pipescript.Datapoint{
+				Timestamp: r.start,
+				Duration:  dp.Timestamp + dp.Duration - r.start,
+				Data:      r.values,
+			}
+			if p.timeout > 0 {
+				p.held = append(p.held, heldMatch{deadline: dp.Timestamp + p.timeout, dp: m})
+			} else {
+				p.out = append(p.out, m)
+			}
+			return nil
+		}
+		if p.runs[k] == nil || p.runs[k].start <= r.start {
+			p.runs[k] = r
+		}
+	}
+	return nil
+}
+
+func (p *patternIter) Next(e *pipescript.TransformEnv, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+	if p.ref == nil {
+		b := pipescript.NewBuffer(pipescript.IteratorFromBI{BI: e.Iter})
+		p.ref = b.Iterator()
+		p.steps.InputIterator(pipescript.IteratorFromBI{BI: b.Iterator()})
+		p.absent.InputIterator(pipescript.IteratorFromBI{BI: b.Iterator()})
+	}
+	var sv, av pipescript.Datapoint
+	for len(p.out) == 0 {
+		if p.done {
+			return nil, nil
+		}
+		dp, err := p.ref.Next()
+		if err != nil {
+			return nil, err
+		}
+		if dp == nil {
+			// Nothing can cancel the held matches once the stream ends
+			p.done = true
+			for _, h := range p.held {
+				p.out = append(p.out, h.dp)
+			}
+			p.held = nil
+			continue
+		}
+		s, err := p.steps.Next(&sv)
+		if err != nil {
+			return nil, err
+		}
+		a, err := p.absent.Next(&av)
+		if err != nil {
+			return nil, err
+		}
+		if s == nil || a == nil {
+			return nil, errors.New("pattern: the conditions must give a value for each datapoint")
+		}
+		if err = p.add(dp, s, a); err != nil {
+			return nil, err
+		}
+	}
+	*out = p.out[0]
+	p.out = p.out[1:]
+	return out, nil
+}
+
+var Pattern = &pipescript.Transform{
+	Name:          "pattern",
+	Description:   "Finds sequences of datapoints that match a list of conditions in order, returning the data of each match",
+	Documentation: string(resources.MustAsset("docs/transforms/pattern.md")),
+	Args: []pipescript.TransformArg{
+		{
+			Name:        "steps",
+			Description: "An array of conditions, such as [d==\"unlocked\", d==\"app\", d==\"locked\"], which must be true for datapoints in the given order",
+			Type:        pipescript.OneToOnePipeArgType,
+		},
+		{
+			Name:        "within",
+			Description: "The maximum number of seconds from the first to the last datapoint of a match. 0 has no limit.",
+			Type:        pipescript.ConstArgType,
+			Optional:    true,
+			Schema: map[string]interface{}{
+				"type":    "number",
+				"minimum": 0,
+				"default": 0,
+			},
+		},
+		{
+			Name:        "gap",
+			Description: "The maximum number of seconds between each step and the next. An array gives a different limit after each step. 0 has no limit.",
+			Type:        pipescript.ConstArgType,
+			Optional:    true,
+			Schema: map[string]interface{}{
+				"oneOf": []interface{}{
+					map[string]interface{}{
+						"type":    "number",
+						"minimum": 0,
+					},
+					map[string]interface{}{
+						"type": "array",
+						"items": map[string]interface{}{
+							"type":    "number",
+							"minimum": 0,
+						},
+					},
+				},
+				"default": 0,
+			},
+		},
+		{
+			Name:        "absent",
+			Description: "A condition that must not be true for any datapoint during a match, or an array with a condition that must not be true after each step until the next one",
+			Type:        pipescript.OneToOnePipeArgType,
+			Optional:    true,
+			Default:     pipescript.MustPipe(pipescript.NewConstTransform(false), nil),
+		},
+		{
+			Name:        "timeout",
+			Description: "The number of seconds after the last step during which the absent condition must not be true, before the match is returned",
+			Type:        pipescript.ConstArgType,
+			Optional:    true,
+			Schema: map[string]interface{}{
+				"type":    "number",
+				"minimum": 0,
+				"default": 0,
+			},
+		},
+	},
+	Constructor: func(transform *pipescript.Transform, consts []interface{}, pipes []*pipescript.Pipe) (pipescript.TransformIterator, error) {
+		p := &patternIter{
+			within:  consts[0].(float64),
+			timeout: consts[2].(float64),
+			steps:   pipes[0],
+			absent:  pipes[1],
+		}
+		if v, ok := consts[1].([]interface{}); ok {
+			p.gaps = make([]float64, len(v))
+			for i := range v {
+				p.gaps[i], _ = pipescript.Float(v[i])
+			}
+		} else {
+			p.gap, _ = pipescript.Float(consts[1])
+		}
+
+		// When the steps are given directly as an array, the other args can be checked against them here
+		if nsteps, ok := arrayLength(p.steps); ok {
+			nabsent, ok := arrayLength(p.absent)
+			if !ok {
+				nabsent = -1
+			}
+			if err := p.checkSteps(nsteps, nabsent); err != nil {
+				return nil, err
+			}
+		}
+		return p, nil
+	},
+}
@@ -0,0 +1,152 @@
+package misc
+
+import (
+	"testing"
+
+	"github.com/heedy/pipescript"
+)
+
+func TestPattern(t *testing.T) {
+	Register()
+	phone := []pipescript.Datapoint{
+		{Timestamp: 0, Data: "unlocked"},
+		{Timestamp: 10, Data: "app"},
+		{Timestamp: 30, Data: "locked"},
+		{Timestamp: 100, Data: "unlocked"},
+		{Timestamp: 300, Data: "locked"},
+	}
+	pipescript.TestCase{
+		Pipescript: "pattern([d=='unlocked', d=='app', d=='locked'])",
+		Input:      phone,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 0, Duration: 30, Data: []interface{}{"unlocked", "app", "locked"}},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "pattern([d=='unlocked', d=='locked'])",
+		Input:      phone,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 0, Duration: 30, Data: []interface{}{"unlocked", "locked"}},
+			{Timestamp: 100, Duration: 200, Data: []interface{}{"unlocked", "locked"}},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "pattern([d=='unlocked', d=='locked'], within=2m)",
+		Input:      phone,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 0, Duration: 30, Data: []interface{}{"unlocked", "locked"}},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "pattern([d=='unlocked', d=='locked'], absent=d=='app')",
+		Input:      phone,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 100, Duration: 200, Data: []interface{}{"unlocked", "locked"}},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "pattern([d=='unlocked', d=='app', d=='locked'], gap=[5, 30])",
+		Input:      phone,
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "pattern([d=='unlocked', d=='app', d=='locked'], gap=[10, 30])",
+		Input:      phone,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 0, Duration: 30, Data: []interface{}{"unlocked", "app", "locked"}},
+		},
+	}.Run(t)
+
+	// The latest start gives the shortest match, and matches don't overlap
+	pipescript.TestCase{
+		Pipescript: "pattern([d==1, d==2], 0, 0)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1},
+			{Timestamp: 2, Data: 1},
+			{Timestamp: 3, Data: 2},
+			{Timestamp: 4, Data: 2},
+			{Timestamp: 5, Data: 1},
+			{Timestamp: 6, Data: 3},
+			{Timestamp: 7, Data: 2},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 2, Duration: 1, Data: []interface{}{1, 2}},
+			{Timestamp: 5, Duration: 2, Data: []interface{}{1, 2}},
+		},
+	}.Run(t)
+
+	// A datapoint is only used once in a match
+	pipescript.TestCase{
+		Pipescript: "pattern([d>0, d>0])",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1},
+			{Timestamp: 2, Data: 2},
+			{Timestamp: 3, Data: 3},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Duration: 1, Data: []interface{}{1, 2}},
+		},
+	}.Run(t)
+
+	// The conditions in an array of absent conditions only apply after their step
+	abc := []pipescript.Datapoint{
+		{Timestamp: 1, Data: "a"},
+		{Timestamp: 2, Data: "b"},
+		{Timestamp: 3, Data: "x"},
+		{Timestamp: 4, Data: "c"},
+	}
+	pipescript.TestCase{
+		Pipescript: "pattern([d=='a', d=='b', d=='c'], absent=[d=='x', false, false])",
+		Input:      abc,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Duration: 3, Data: []interface{}{"a", "b", "c"}},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "pattern([d=='a', d=='b', d=='c'], absent=[false, d=='x', false])",
+		Input:      abc,
+	}.Run(t)
+
+	// With a timeout, the absent condition of the last step gives a step that must not follow the match
+	pipescript.TestCase{
+		Pipescript: "pattern([d=='unlocked'], absent=[d=='app'], timeout=1m)",
+		Input:      phone,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 100, Data: []interface{}{"unlocked"}},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "pattern([d=='unlocked', d=='locked'], absent=[false, d=='unlocked'], timeout=100)",
+		Input:      phone,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 100, Duration: 200, Data: []interface{}{"unlocked", "locked"}},
+		},
+	}.Run(t)
+
+	// The number of gaps and absent conditions are checked when the steps are an array
+	pipescript.TestCase{
+		Pipescript: "pattern([d==1, d==2, d==3], gap=[1])",
+		Parsed:     "error",
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "pattern([d==1, d==2], absent=[d==3])",
+		Parsed:     "error",
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "pattern(d('steps'), gap=[1])",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"steps": []interface{}{true, false, false}}},
+		},
+		OutputError: true,
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "pattern(d==1)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1},
+		},
+		OutputError: true,
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "pattern([d==1], within=-1)",
+		Parsed:     "error",
+	}.Run(t)
+}
@@ -0,0 +1,235 @@
+package misc
+
+import (
+	"github.com/heedy/pipescript"
+	"github.com/heedy/pipescript/resources"
+)
+
+type geoPoint struct {
+	ts        float64
+	lat, long float64
+}
+
+// stay is a run of locations within the radius of its first location, with their average location
+type stay struct {
+	first, last geoPoint
+	c           centroid
+}
+
+// staySegmenter splits a stream of locations into stays, where the user was within the radius of the first location
+// of the stay for at least minduration seconds, and the movement between them. Each stay is passed to onStay once
+// it ends, and the locations that are not part of a stay are passed in order to onMove.
+type staySegmenter struct {
+	radius      float64
+	minduration float64
+
+	onStay func(s *stay)
+	onMove func(p geoPoint)
+
+	// The locations that are all within the radius of the first one, but not yet for minduration. Once they
+	// are, they become the current stay, which only keeps their average location.
+	buf     []geoPoint
+	current *stay
+}
+
+func (s *staySegmenter) within(a, b geoPoint) bool {
+	return haversine(a.lat, a.long, b.lat, b.long) <= s.radius
+}
+
+func (s *staySegmenter) add(p geoPoint) {
+	if s.current != nil {
+		if s.within(s.current.first, p) {
+			s.current.last = p
+			s.current.c.add(p.lat, p.long)
+			return
+		}
+		s.onStay(s.current)
+		s.current = nil
+	}
+	if len(s.buf) == 0 || s.within(s.buf[0], p) {
+		s.buf = append(s.buf, p)
+		if p.ts-s.buf[0].ts >= s.minduration {
+			st := &stay{first: s.buf[0], last: p}
+			for _, q := range s.buf {
+				st.c.add(q.lat, q.long)
+			}
+			s.current = st
+			s.buf = s.buf[:0]
+		}
+		return
+	}
+	// The user moved on too soon, so the first location is not part of a stay. The rest of the
+	// locations are checked again with the next one as the start of a stay.
+	s.onMove(s.buf[0])
+	rest := append(s.buf[1:], p)
+	s.buf = nil
+	for _, q := range rest {
+		s.add(q)
+	}
+}
+
+// flush is called at the end of the stream, with the remaining locations either forming a stay or movement
+func (s *staySegmenter) flush() {
+	if s.current != nil {
+		s.onStay(s.current)
+		s.current = nil
+	}
+	for _, p := range s.buf {
+		s.onMove(p)
+	}
+	s.buf = nil
+}
+
+// segmentIter runs a staySegmenter over the locations of a stream, with the callbacks adding datapoints to its output
+type segmentIter struct {
+	seg  staySegmenter
+	out  []pipescript.Datapoint
+	done bool
+}
+
+func (s *segmentIter) OneToOne() bool {
+	return false
+}
+
+func (s *segmentIter) Next(e *pipescript.TransformEnv, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+	for len(s.out) == 0 {
+		if s.done {
+			return nil, nil
+		}
+		dp, _, err := e.Next(nil)
+		if err != nil {
+			return nil, err
+		}
+		if dp == nil {
+			s.done = true
+			s.seg.flush()
+			continue
+		}
+		// Null values are skipped
+		if dp.Data != nil {
+			lat, long, err := location(dp.Data)
+			if err != nil {
+				return nil, err
+			}
+			s.seg.add(geoPoint{ts: dp.Timestamp, lat: lat, long: long})
+		}
+	}
+	*out = s.out[0]
+	s.out = s.out[1:]
+	return out, nil
+}
+
+// segmentArgs returns the args of the transforms that split locations into stays
+func segmentArgs() []pipescript.TransformArg {
+	return []pipescript.TransformArg{
+		{
+			Name:        "radius",
+			Description: "The distance in meters that the user can move from the first location of a stay while staying",
+			Type:        pipescript.ConstArgType,
+			Optional:    true,
+			Schema: map[string]interface{}{
+				"type":    "number",
+				"minimum": 0,
+				"default": 200,
+			},
+		},
+		{
+			Name:        "minduration",
+			Description: "The minimum number of seconds that the user must stay within the radius for it to be a stay",
+			Type:        pipescript.ConstArgType,
+			Optional:    true,
+			Schema: map[string]interface{}{
+				"type":    "number",
+				"minimum": 0,
+				"default": 1200,
+			},
+		},
+	}
+}
+
+var Staypoints = &pipescript.Transform{
+	Name:          "staypoints",
+	Description:   "Finds the places where the user stayed, returning the average location of each stay, with the time of arrival and the duration of the stay",
+	Documentation: string(resources.MustAsset("docs/transforms/staypoints.md")),
+	InputSchema:   locationSchema,
+	OutputSchema:  locationSchema,
+	Args:          segmentArgs(),
+	Constructor: func(transform *pipescript.Transform, consts []interface{}, pipes []*pipescript.Pipe) (pipescript.TransformIterator, error) {
+		s := &segmentIter{}
+		s.seg = staySegmenter{
+			radius:      consts[0].(float64),
+			minduration: consts[1].(float64),
+			onStay: func(st *stay) {
+				s.out = append(s.out, pipescript.Datapoint{
+					Timestamp: st.first.ts,
+					Duration:  st.last.ts - st.first.ts,
+					Data:      st.c.location(),
+				})
+			},
+			onMove: func(p geoPoint) {},
+		}
+		return s, nil
+	},
+}
+
+var Trips = &pipescript.Transform{
+	Name:          "trips",
+	Description:   "Finds the movement between the places where the user stayed, returning the distance and average speed of each trip",
+	Documentation: string(resources.MustAsset("docs/transforms/trips.md")),
+	InputSchema:   locationSchema,
+	OutputSchema: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"distance": map[string]interface{}{
+				"type": "number",
+			},
+			"speed": map[string]interface{}{
+				"type": "number",
+			},
+		},
+	},
+	Args: segmentArgs(),
+	Constructor: func(transform *pipescript.Transform, consts []interface{}, pipes []*pipescript.Pipe) (pipescript.TransformIterator, error) {
+		s := &segmentIter{}
+
+		// A trip goes from the last location of a stay, through the locations that are not part of a stay,
+		// to the first location of the next stay. The movement before the first stay and after the last one
+		// is not a trip, since where it started or ended is not known.
+		var start *geoPoint
+		var prev geoPoint
+		dist := float64(0)
+		s.seg = staySegmenter{
+			radius:      consts[0].(float64),
+			minduration: consts[1].(float64),
+			onStay: func(st *stay) {
+				if start != nil {
+					dist += haversine(prev.lat, prev.long, st.first.lat, st.first.long)
+					dt := st.first.ts - start.ts
+					var speed interface{}
+					if dt > 0 {
+						speed = dist / dt
+					}
+					s.out = append(s.out, pipescript.Datapoint{
+						Timestamp: start.ts,
+						Duration:  dt,
+						Data: map[string]interface{}{
+							"distance": dist,
+							"speed":    speed,
+						},
+					})
+				}
+				last := st.last
+				start = &last
+				prev = last
+				dist = 0
+			},
+			onMove: func(p geoPoint) {
+				if start != nil {
+					dist += haversine(prev.lat, prev.long, p.lat, p.long)
+					prev = p
+				}
+			},
+		}
+		return s, nil
+	},
+}
@@ -0,0 +1,105 @@
+package misc
+
+import (
+	"testing"
+
+	"github.com/heedy/pipescript"
+	"github.com/stretchr/testify/require"
+)
+
+var day = []pipescript.Datapoint{
+	{Timestamp: 0, Data: map[string]interface{}{"latitude": 40.4243, "longitude": -86.9114}},
+	{Timestamp: 600, Data: map[string]interface{}{"latitude": 40.4245, "longitude": -86.9114}},
+	{Timestamp: 1200, Data: map[string]interface{}{"latitude": 40.4243, "longitude": -86.9112}},
+	{Timestamp: 1800, Data: map[string]interface{}{"latitude": 40.4243, "longitude": -86.9114}},
+	{Timestamp: 2100, Data: map[string]interface{}{"latitude": 40.4300, "longitude": -86.9114}},
+	{Timestamp: 2400, Data: map[string]interface{}{"latitude": 40.4350, "longitude": -86.9114}},
+	{Timestamp: 2700, Data: map[string]interface{}{"latitude": 40.4400, "longitude": -86.9114}},
+	{Timestamp: 3300, Data: map[string]interface{}{"latitude": 40.4401, "longitude": -86.9114}},
+	{Timestamp: 3900, Data: map[string]interface{}{"latitude": 40.4400, "longitude": -86.9113}},
+	{Timestamp: 4000, Data: nil},
+}
+
+func TestStaypoints(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "staypoints(200, 10m)",
+		Input:      day,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 0, Duration: 1800, Data: map[string]interface{}{"latitude": 40.42435000003231, "longitude": -86.91134999996284}},
+			{Timestamp: 2700, Duration: 1200, Data: map[string]interface{}{"latitude": 40.440033333342896, "longitude": -86.91136666665014}},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "staypoints(200, 30m)",
+		Input:      day,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 0, Duration: 1800, Data: map[string]interface{}{"latitude": 40.42435000003231, "longitude": -86.91134999996284}},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "staypoints(200, 2h)",
+		Input:      day,
+		Output:     []pipescript.Datapoint{},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "staypoints(-1)",
+		Parsed:     "error",
+	}.Run(t)
+}
+
+func TestTrips(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "trips(200, 10m)",
+		Input:      day,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1800, Duration: 900, Data: map[string]interface{}{"distance": 1745.7603483188411, "speed": 1.939733720354268}},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		// The movement after the last stay is not a trip, since it didn't arrive anywhere
+		Pipescript: "trips(200, 10m)",
+		Input: append(append([]pipescript.Datapoint{}, day...), pipescript.Datapoint{
+			Timestamp: 4500, Data: map[string]interface{}{"latitude": 40.45, "longitude": -86.9113},
+		}),
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1800, Duration: 900, Data: map[string]interface{}{"distance": 1745.7603483188411, "speed": 1.939733720354268}},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		// Work is not a stay, so the movement from home never arrives
+		Pipescript: "trips(200, 30m)",
+		Input:      day,
+		Output:     []pipescript.Datapoint{},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "trips(200, 2h)",
+		Input:      day,
+		Output:     []pipescript.Datapoint{},
+	}.Run(t)
+}
+
+func TestStaySegmenter(t *testing.T) {
+	var stays []*stay
+	moves := 0
+	seg := staySegmenter{
+		radius:      100,
+		minduration: 60,
+		onStay:      func(s *stay) { stays = append(stays, s) },
+		onMove:      func(p geoPoint) { moves++ },
+	}
+	// Once a stay lasts for minduration, its locations are no longer kept
+	for i := 0; i < 1000; i++ {
+		seg.add(geoPoint{ts: float64(i * 10), lat: 40.4243, long: -86.9114})
+	}
+	require.Empty(t, seg.buf)
+	require.NotNil(t, seg.current)
+
+	seg.add(geoPoint{ts: 10000, lat: 41, long: -86.9114})
+	seg.flush()
+	require.Len(t, stays, 1)
+	require.Equal(t, float64(0), stays[0].first.ts)
+	require.Equal(t, float64(9990), stays[0].last.ts)
+	require.Equal(t, 1, moves)
+}
@@ -0,0 +1,328 @@
+package numeric
+
+import (
+	"math"
+	"sort"
+
+	"github.com/heedy/pipescript"
+	"github.com/heedy/pipescript/resources"
+)
+
+// rollingWindow holds the values of the previous datapoints, limited either to the given number of seconds before
+// the current datapoint, or to the given number of datapoints. A size of 0 keeps all previous values.
+type rollingWindow struct {
+	size   float64
+	count  bool // Whether the size is a number of datapoints
+	sorted bool // Whether to keep a sorted copy of the values, for quantiles
+
+	ts     []float64
+	vals   []float64
+	sortv  []float64
+	n      int
+	shift  float64 // The sums are of values minus the first value seen, which keeps the variance accurate
+	sum    float64
+	sumsq  float64
+	hasAny bool
+}
+
+// expire removes the values that are outside the window of a datapoint at the given timestamp
+func (w *rollingWindow) expire(ts float64) {
+	if w.size <= 0 {
+		return
+	}
+	i := 0
+	if w.count {
+		i = len(w.vals) - int(w.size)
+	} else {
+		for i < len(w.ts) && w.ts[i] < ts-w.size {
+			i++
+		}
+	}
+	for j := 0; j < i; j++ {
+		v := w.vals[j]
+		w.n--
+		w.sum -= v - w.shift
+		w.sumsq -= (v - w.shift) * (v - w.shift)
+		if w.sorted {
+			k := sort.SearchFloat64s(w.sortv, v)
+			w.sortv = append(w.sortv[:k], w.sortv[k+1:]...)
+		}
+	}
+	if i > 0 {
+		w.ts = w.ts[i:]
+		w.vals = w.vals[i:]
+	}
+}
+
+func (w *rollingWindow) add(ts, v float64) {
+	if !w.hasAny {
+		w.shift = v
+		w.hasAny = true
+	}
+	w.n++
+	w.sum += v - w.shift
+	w.sumsq += (v - w.shift) * (v - w.shift)
+	if w.size > 0 {
+		w.ts = append(w.ts, ts)
+		w.vals = append(w.vals, v)
+	}
+	if w.sorted {
+		k := sort.SearchFloat64s(w.sortv, v)
+		w.sortv = append(w.sortv, 0)
+		copy(w.sortv[k+1:], w.sortv[k:])
+		w.sortv[k] = v
+	}
+}
+
+// meanStd returns the mean and sample standard deviation of the values in the window
+func (w *rollingWindow) meanStd() (float64, float64, bool) {
+	if w.n < 2 {
+		return 0, 0, false
+	}
+	n := float64(w.n)
+	m := w.sum / n
+	variance := (w.sumsq - w.sum*m) / (n - 1)
+	if variance < 0 {
+		variance = 0
+	}
+	return m + w.shift, math.Sqrt(variance), true
+}
+
+// quantile returns the q quantile of sorted values, interpolating linearly between them
+func quantile(sorted []float64, q float64) float64 {
+	pos := q * float64(len(sorted)-1)
+	i := int(pos)
+	if i+1 >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[i] + (pos-float64(i))*(sorted[i+1]-sorted[i])
+}
+
+// spreadScore returns the distance of v from the center in units of the spread. If the spread is 0,
+// the score is infinite for values that are not at the center.
+func spreadScore(v, center, spread float64) float64 {
+	if spread == 0 {
+		if v == center {
+			return 0
+		}
+		return math.Copysign(math.Inf(1), v-center)
+	}
+	return (v - center) / spread
+}
+
+// anomalyScorer returns the score of the value given the window of previous values,
+// and false if there is not enough data for a score
+type anomalyScorer func(w *rollingWindow, v float64) (float64, bool)
+
+type anomalyIter struct {
+	w         rollingWindow
+	threshold *float64
+	score     anomalyScorer
+}
+
+func (a *anomalyIter) OneToOne() bool {
+	return true
+}
+
+func (a *anomalyIter) Next(e *pipescript.TransformEnv, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+	dp, _, err := e.Next(nil)
+	if err != nil || dp == nil {
+		return nil, err
+	}
+	out.Timestamp = dp.Timestamp
+	out.Duration = dp.Duration
+	out.Data = nil
+	if a.threshold != nil {
+		out.Data = false
+	}
+	if dp.Data == nil {
+		// Null values are skipped
+		return out, nil
+	}
+	v, err := dp.Float()
+	if err != nil {
+		return nil, err
+	}
+
+	a.w.expire(dp.Timestamp)
+	s, ok := a.score(&a.w, v)
+	a.w.add(dp.Timestamp, v)
+
+	// When the spread of the previous values is 0, values that are not at the center have an infinite score.
+	// There is no meaningful score for them, so they are null, and not above the threshold, in both modes.
+	ok = ok && !math.IsInf(s, 0)
+	if a.threshold != nil {
+		out.Data = ok && math.Abs(s) > *a.threshold
+	} else if ok {
+		out.Data = s
+	}
+	return out, nil
+}
+
+// anomalyArgs returns the args of a transform that scores each datapoint by the previous values in its window
+func anomalyArgs(threshold interface{}, thresholdDescription string) []pipescript.TransformArg {
+	return []pipescript.TransformArg{
+		{
+			Name:        "window",
+			Description: "The size of the window of previous datapoints that the score is based on, in seconds. 0 uses all previous datapoints.",
+			Type:        pipescript.ConstArgType,
+			Optional:    true,
+			Schema: map[string]interface{}{
+				"type":    "number",
+				"minimum": 0,
+				"default": 0,
+			},
+		},
+		{
+			Name:        "by",
+			Description: "Whether the window is a duration ('time') or a number of datapoints ('count')",
+			Type:        pipescript.ConstArgType,
+			Optional:    true,
+			Schema: map[string]interface{}{
+				"type":    "string",
+				"enum":    []interface{}{"time", "count"},
+				"default": "time",
+			},
+		},
+		{
+			Name:        "threshold",
+			Description: thresholdDescription,
+			Type:        pipescript.ConstArgType,
+			Optional:    true,
+			Schema: map[string]interface{}{
+				"type":    []interface{}{"number", "null"},
+				"minimum": 0,
+				"default": threshold,
+			},
+		},
+	}
+}
+
+// anomalyConstructor creates the iterator of a transform whose args start with anomalyArgs. The scorer
+// is created with all of the transform's const args.
+func anomalyConstructor(sorted bool, scorer func(consts []interface{}) anomalyScorer) pipescript.TransformConstructor {
+	return func(transform *pipescript.Transform, consts []interface{}, pipes []*pipescript.Pipe) (pipescript.TransformIterator, error) {
+		a := &anomalyIter{
+			w: rollingWindow{
+				size:   consts[0].(float64),
+				count:  consts[1].(string) == "count",
+				sorted: sorted,
+			},
+			score: scorer(consts),
+		}
+		if consts[2] != nil {
+			t := consts[2].(float64)
+			a.threshold = &t
+		}
+		return a, nil
+	}
+}
+
+var Zscore = &pipescript.Transform{
+	Name:          "zscore",
+	Description:   "Returns the number of standard deviations between each value and the mean of the previous values",
+	Documentation: string(resources.MustAsset("docs/transforms/zscore.md")),
+	InputSchema: map[string]interface{}{
+		"type": "number",
+	},
+	Args: anomalyArgs(nil, "If given, returns true when the absolute z-score is greater than the threshold, and false otherwise"),
+	Constructor: anomalyConstructor(false, func(consts []interface{}) anomalyScorer {
+		return func(w *rollingWindow, v float64) (float64, bool) {
+			m, std, ok := w.meanStd()
+			return spreadScore(v, m, std), ok
+		}
+	}),
+}
+
+var IQROutlier = &pipescript.Transform{
+	Name:          "iqr_outlier",
+	Description:   "Returns true for values outside of the interquartile range of the previous values by more than the threshold times the range",
+	Documentation: string(resources.MustAsset("docs/transforms/iqr_outlier.md")),
+	InputSchema: map[string]interface{}{
+		"type": "number",
+	},
+	Args: anomalyArgs(1.5, "Values more than this many interquartile ranges outside of the quartiles are outliers. If null, returns the number of ranges outside the quartiles instead."),
+	Constructor: anomalyConstructor(true, func(consts []interface{}) anomalyScorer {
+		return func(w *rollingWindow, v float64) (float64, bool) {
+			if w.n < 2 {
+				return 0, false
+			}
+			q1 := quantile(w.sortv, 0.25)
+			q3 := quantile(w.sortv, 0.75)
+			switch {
+			case v > q3:
+				return spreadScore(v, q3, q3-q1), true
+			case v < q1:
+				return spreadScore(v, q1, q3-q1), true
+			}
+			return 0, true
+		}
+	}),
+}
+
+var MADZscore = &pipescript.Transform{
+	Name:          "mad_zscore",
+	Description:   "Returns the modified z-score of each value, which uses the median and median absolute deviation of the previous values",
+	Documentation: string(resources.MustAsset("docs/transforms/mad_zscore.md")),
+	InputSchema: map[string]interface{}{
+		"type": "number",
+	},
+	Args: anomalyArgs(nil, "If given, returns true when the absolute modified z-score is greater than the threshold, and false otherwise"),
+	Constructor: anomalyConstructor(true, func(consts []interface{}) anomalyScorer {
+		var dev []float64
+		return func(w *rollingWindow, v float64) (float64, bool) {
+			if w.n < 2 {
+				return 0, false
+			}
+			med := quantile(w.sortv, 0.5)
+			dev = dev[:0]
+			for _, x := range w.sortv {
+				dev = append(dev, math.Abs(x-med))
+			}
+			sort.Float64s(dev)
+			// The constant makes the score comparable to a z-score for normally distributed data
+			return 0.6745 * spreadScore(v, med, quantile(dev, 0.5)), true
+		}
+	}),
+}
+
+var Cusum = &pipescript.Transform{
+	Name:          "cusum",
+	Description:   "Detects shifts in the mean of a stream by accumulating deviations from the mean of the previous values",
+	Documentation: string(resources.MustAsset("docs/transforms/cusum.md")),
+	InputSchema: map[string]interface{}{
+		"type": "number",
+	},
+	Args: append(anomalyArgs(nil, "If given, returns true when the cumulative sum is greater than the threshold, and resets the sums"), pipescript.TransformArg{
+		Name:        "drift",
+		Description: "The number of standard deviations that a value can differ from the mean without being added to the sums",
+		Type:        pipescript.ConstArgType,
+		Optional:    true,
+		Schema: map[string]interface{}{
+			"type":    "number",
+			"minimum": 0,
+			"default": 0.5,
+		},
+	}),
+	Constructor: anomalyConstructor(false, func(consts []interface{}) anomalyScorer {
+		drift := consts[3].(float64)
+		var pos, neg float64
+		return func(w *rollingWindow, v float64) (float64, bool) {
+			z := float64(0)
+			if m, std, ok := w.meanStd(); ok && std > 0 {
+				z = (v - m) / std
+			}
+			pos = math.Max(0, pos+z-drift)
+			neg = math.Max(0, neg-z-drift)
+			s := pos
+			if neg > pos {
+				s = -neg
+			}
+			if t, ok := consts[2].(float64); ok && math.Abs(s) > t {
+				// The change was detected, so start looking for the next one
+				pos, neg = 0, 0
+			}
+			return s, true
+		}
+	}),
+}
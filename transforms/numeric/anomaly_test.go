@@ -0,0 +1,233 @@
+package numeric
+
+import (
+	"testing"
+
+	"github.com/heedy/pipescript"
+)
+
+var heartrate = []pipescript.Datapoint{
+	{Timestamp: 0, Data: 70},
+	{Timestamp: 60, Data: 72},
+	{Timestamp: 120, Data: 71},
+	{Timestamp: 180, Data: 73},
+	{Timestamp: 240, Data: 120},
+	{Timestamp: 300, Data: 72},
+}
+
+func TestZscore(t *testing.T) {
+	Zscore.Register()
+	pipescript.TestCase{
+		Pipescript: "zscore(1h)",
+		Input:      heartrate,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 0, Data: nil},
+			{Timestamp: 60, Data: nil},
+			{Timestamp: 120, Data: float64(0)},
+			{Timestamp: 180, Data: float64(2)},
+			{Timestamp: 240, Data: 37.56793845821194},
+			{Timestamp: 300, Data: -0.42359896394546465},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "zscore(1h, threshold=3)",
+		Input:      heartrate,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 0, Data: false},
+			{Timestamp: 60, Data: false},
+			{Timestamp: 120, Data: false},
+			{Timestamp: 180, Data: false},
+			{Timestamp: 240, Data: true},
+			{Timestamp: 300, Data: false},
+		},
+	}.Run(t)
+
+	// The window only holds the previous datapoints within its bounds
+	pipescript.TestCase{
+		Pipescript: "zscore(2, 'count')",
+		Input:      heartrate,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 0, Data: nil},
+			{Timestamp: 60, Data: nil},
+			{Timestamp: 120, Data: float64(0)},
+			{Timestamp: 180, Data: 2.1213203435596424},
+			{Timestamp: 240, Data: 33.94112549695428},
+			{Timestamp: 300, Data: -0.737196431449805},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "zscore(130)",
+		Input:      heartrate,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 0, Data: nil},
+			{Timestamp: 60, Data: nil},
+			{Timestamp: 120, Data: float64(0)},
+			{Timestamp: 180, Data: 2.1213203435596424},
+			{Timestamp: 240, Data: 33.94112549695428},
+			{Timestamp: 300, Data: -0.737196431449805},
+		},
+	}.Run(t)
+
+	// Equal previous values have no spread, and nulls are skipped
+	pipescript.TestCase{
+		Pipescript: "zscore",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 5},
+			{Timestamp: 2, Data: nil},
+			{Timestamp: 3, Data: 5},
+			{Timestamp: 4, Data: 5},
+			{Timestamp: 5, Data: 6},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: nil},
+			{Timestamp: 2, Data: nil},
+			{Timestamp: 3, Data: nil},
+			{Timestamp: 4, Data: float64(0)},
+			{Timestamp: 5, Data: nil},
+		},
+	}.Run(t)
+	// The threshold gives false where the score is null, so that it agrees with comparing the score
+	pipescript.TestCase{
+		Pipescript: "zscore(threshold=10)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 5},
+			{Timestamp: 2, Data: nil},
+			{Timestamp: 3, Data: 5},
+			{Timestamp: 5, Data: 6},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: false},
+			{Timestamp: 2, Data: false},
+			{Timestamp: 3, Data: false},
+			{Timestamp: 5, Data: false},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "zscore(-1)",
+		Parsed:     "error",
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "zscore(10, 'hours')",
+		Parsed:     "error",
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "zscore",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "hi"},
+		},
+		OutputError: true,
+	}.Run(t)
+}
+
+func TestIQROutlier(t *testing.T) {
+	IQROutlier.Register()
+	pipescript.TestCase{
+		Pipescript: "iqr_outlier",
+		Input:      heartrate,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 0, Data: false},
+			{Timestamp: 60, Data: false},
+			{Timestamp: 120, Data: false},
+			{Timestamp: 180, Data: false},
+			{Timestamp: 240, Data: true},
+			{Timestamp: 300, Data: false},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "iqr_outlier(threshold=null)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 10},
+			{Timestamp: 2, Data: 20},
+			{Timestamp: 3, Data: 30},
+			{Timestamp: 4, Data: 50},
+			{Timestamp: 5, Data: 0},
+			{Timestamp: 6, Data: 20},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: nil},
+			{Timestamp: 2, Data: nil},
+			{Timestamp: 3, Data: 2.5},
+			{Timestamp: 4, Data: 2.5},
+			{Timestamp: 5, Data: float64(-1)},
+			{Timestamp: 6, Data: float64(0)},
+		},
+	}.Run(t)
+}
+
+func TestMADZscore(t *testing.T) {
+	MADZscore.Register()
+	pipescript.TestCase{
+		Pipescript: "mad_zscore",
+		Input:      heartrate,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 0, Data: nil},
+			{Timestamp: 60, Data: nil},
+			{Timestamp: 120, Data: float64(0)},
+			{Timestamp: 180, Data: 1.349},
+			{Timestamp: 240, Data: 32.71325},
+			{Timestamp: 300, Data: float64(0)},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "mad_zscore(threshold=3.5)",
+		Input:      heartrate,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 0, Data: false},
+			{Timestamp: 60, Data: false},
+			{Timestamp: 120, Data: false},
+			{Timestamp: 180, Data: false},
+			{Timestamp: 240, Data: true},
+			{Timestamp: 300, Data: false},
+		},
+	}.Run(t)
+}
+
+func TestCusum(t *testing.T) {
+	Cusum.Register()
+	pipescript.TestCase{
+		Pipescript: "cusum",
+		Input:      heartrate,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 0, Data: float64(0)},
+			{Timestamp: 60, Data: float64(0)},
+			{Timestamp: 120, Data: float64(0)},
+			{Timestamp: 180, Data: 1.5},
+			{Timestamp: 240, Data: 38.56793845821194},
+			{Timestamp: 300, Data: 37.644339494266475},
+		},
+	}.Run(t)
+
+	// Once the threshold is passed, the sums start over
+	pipescript.TestCase{
+		Pipescript: "cusum(threshold=1, drift=0)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1},
+			{Timestamp: 2, Data: 3},
+			{Timestamp: 3, Data: 3},
+			{Timestamp: 4, Data: 9},
+			{Timestamp: 5, Data: 3},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: false},
+			{Timestamp: 2, Data: false},
+			{Timestamp: 3, Data: false},
+			{Timestamp: 4, Data: true},
+			{Timestamp: 5, Data: false},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "cusum(0, 'time', null, 0)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1},
+			{Timestamp: 2, Data: 3},
+			{Timestamp: 3, Data: 3},
+			{Timestamp: 4, Data: 2},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: float64(0)},
+			{Timestamp: 2, Data: float64(0)},
+			{Timestamp: 3, Data: 0.7071067811865475},
+			{Timestamp: 4, Data: 0.4184316465917349},
+		},
+	}.Run(t)
+}
@@ -1,7 +1,6 @@
 package numeric
 
 import (
-	"errors"
 	"fmt"
 	"math"
 
@@ -15,6 +14,7 @@ var Bucket = pipescript.Transform{
 	Documentation: string(resources.MustAsset("docs/transforms/bucket.md")),
 	Args: []pipescript.TransformArg{
 		{
+			Name:        "size",
 			Description: "The size of each bucket",
 			Optional:    true,
 			Default:     pipescript.MustPipe(pipescript.NewConstTransform(10), nil),
@@ -24,6 +24,7 @@ var Bucket = pipescript.Transform{
 			},
 		},
 		{
+			Name:        "start",
 			Description: "Start location for bucketing",
 			Optional:    true,
 			Default:     pipescript.MustPipe(pipescript.NewConstTransform(0), nil),
@@ -39,19 +40,7 @@ var Bucket = pipescript.Transform{
 	OutputSchema: map[string]interface{}{
 		"type": "string",
 	},
-	Constructor: pipescript.NewBasic(func(consts []interface{}, pipes []*pipescript.Pipe) ([]interface{}, []*pipescript.Pipe, error) {
-		f, ok := pipescript.Float(consts[0])
-		if !ok {
-			return nil, nil, errors.New("bucket size must be a number")
-		}
-		consts[0] = f
-		f, ok = pipescript.Float(consts[1])
-		if !ok {
-			return nil, nil, errors.New("bucket start location must be a number")
-		}
-		consts[1] = f
-		return consts, pipes, nil
-	}, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+	Constructor: pipescript.NewBasic(nil, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
 		f, err := dp.Float()
 		if err != nil {
 			return nil, err
@@ -40,3 +40,39 @@ func TestBucket(t *testing.T) {
 		},
 	}.Run(t)
 }
+
+func TestBucketKeywords(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "bucket(start=5)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 2},
+			{Timestamp: 2, Data: 16},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "[-5,5)"},
+			{Timestamp: 2, Data: "[15,25)"},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "bucket(start=1, size=5)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 2},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "[1,6)"},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "bucket(5, size=5)",
+		Parsed:     "error",
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "bucket(begin=5)",
+		Parsed:     "error",
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "bucket('a')",
+		Parsed:     "error",
+	}.Run(t)
+}
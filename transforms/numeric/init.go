@@ -1,5 +1,5 @@
 /*
-Package numeric contains basic statistical transforms (such as mean)
+Package numeric contains basic statistical transforms (such as mean) and math functions
 */
 package numeric
 
@@ -12,6 +12,35 @@ func Register() {
 
 	Max.Register()
 	Min.Register()
+
+	Abs.Register()
+	Sign.Register()
+	Floor.Register()
+	Ceil.Register()
+	Round.Register()
+	Clamp.Register()
+	Sqrt.Register()
+	Log.Register()
+	Log10.Register()
+	Exp.Register()
+	Sin.Register()
+	Cos.Register()
+	Tan.Register()
+	Asin.Register()
+	Acos.Register()
+	Atan.Register()
+	Least.Register()
+	Greatest.Register()
+
+	Zscore.Register()
+	IQROutlier.Register()
+	MADZscore.Register()
+	Cusum.Register()
+
+	Cov.Register()
+	Corr.Register()
+	Linreg.Register()
+	Trend.Register()
 	/*
 		Percent.Register()
 	*/
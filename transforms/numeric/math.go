@@ -0,0 +1,284 @@
+package numeric
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/heedy/pipescript"
+	"github.com/heedy/pipescript/resources"
+)
+
+// mathValue returns the value of numeric data. Integer types (int, int64) are returned as int64
+// with isInt set, so that transforms which keep integers whole (abs, floor, clamp...) can give an integer result.
+// Everything else is converted with pipescript.Float.
+func mathValue(v interface{}) (i int64, f float64, isInt bool, err error) {
+	switch n := v.(type) {
+	case int:
+		return int64(n), float64(n), true, nil
+	case int64:
+		return n, float64(n), true, nil
+	}
+	f, ok := pipescript.Float(v)
+	if !ok {
+		return 0, 0, false, errors.New("Math functions can only be used on numbers")
+	}
+	return 0, f, false, nil
+}
+
+// mathResult returns null for results that are not real numbers, such as sqrt(-1) or log(0),
+// since they can't be represented in JSON
+func mathResult(f float64) interface{} {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil
+	}
+	return f
+}
+
+var numberSchema = map[string]interface{}{
+	"type": "number",
+}
+
+// newFloatFunc creates a transform which applies the given function to numeric data.
+// Null data gives null.
+func newFloatFunc(name, description string, f func(float64) float64) *pipescript.Transform {
+	return &pipescript.Transform{
+		Name:         name,
+		Description:  description,
+		InputSchema:  numberSchema,
+		OutputSchema: numberSchema,
+		Constructor: pipescript.NewBasic(nil, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+			if dp.Data == nil {
+				out.Data = nil
+				return out, nil
+			}
+			_, v, _, err := mathValue(dp.Data)
+			if err != nil {
+				return nil, err
+			}
+			out.Data = mathResult(f(v))
+			return out, nil
+		}),
+	}
+}
+
+// newIntFunc is like newFloatFunc, but integer data is passed to fi, so that the result stays an integer
+func newIntFunc(name, description string, fi func(int64) int64, f func(float64) float64) *pipescript.Transform {
+	return &pipescript.Transform{
+		Name:         name,
+		Description:  description,
+		InputSchema:  numberSchema,
+		OutputSchema: numberSchema,
+		Constructor: pipescript.NewBasic(nil, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+			if dp.Data == nil {
+				out.Data = nil
+				return out, nil
+			}
+			i, v, isInt, err := mathValue(dp.Data)
+			if err != nil {
+				return nil, err
+			}
+			if isInt {
+				out.Data = fi(i)
+			} else {
+				out.Data = mathResult(f(v))
+			}
+			return out, nil
+		}),
+	}
+}
+
+func intIdentity(i int64) int64 {
+	return i
+}
+
+var (
+	Abs = newIntFunc("abs", "Returns the absolute value of a number", func(i int64) int64 {
+		if i < 0 {
+			return -i
+		}
+		return i
+	}, math.Abs)
+	Sign = newIntFunc("sign", "Returns -1 for negative numbers, 1 for positive numbers, and 0 for 0", func(i int64) int64 {
+		switch {
+		case i < 0:
+			return -1
+		case i > 0:
+			return 1
+		}
+		return 0
+	}, func(f float64) float64 {
+		switch {
+		case f < 0:
+			return -1
+		case f > 0:
+			return 1
+		}
+		return 0
+	})
+	Floor = newIntFunc("floor", "Rounds a number down to the nearest integer", intIdentity, math.Floor)
+	Ceil  = newIntFunc("ceil", "Rounds a number up to the nearest integer", intIdentity, math.Ceil)
+
+	Sqrt  = newFloatFunc("sqrt", "Returns the square root of a number", math.Sqrt)
+	Log   = newFloatFunc("log", "Returns the natural logarithm of a number", math.Log)
+	Log10 = newFloatFunc("log10", "Returns the base 10 logarithm of a number", math.Log10)
+	Exp   = newFloatFunc("exp", "Returns e raised to the power of the number", math.Exp)
+
+	Sin  = newFloatFunc("sin", "Returns the sine of an angle given in radians", math.Sin)
+	Cos  = newFloatFunc("cos", "Returns the cosine of an angle given in radians", math.Cos)
+	Tan  = newFloatFunc("tan", "Returns the tangent of an angle given in radians", math.Tan)
+	Asin = newFloatFunc("asin", "Returns the arcsine of a number, in radians", math.Asin)
+	Acos = newFloatFunc("acos", "Returns the arccosine of a number, in radians", math.Acos)
+	Atan = newFloatFunc("atan", "Returns the arctangent of a number, in radians", math.Atan)
+)
+
+var Round = &pipescript.Transform{
+	Name:          "round",
+	Description:   "Rounds a number to the given number of decimal places",
+	Documentation: string(resources.MustAsset("docs/transforms/round.md")),
+	InputSchema:   numberSchema,
+	OutputSchema:  numberSchema,
+	Args: []pipescript.TransformArg{
+		{
+			Name:        "digits",
+			Description: "The number of digits after the decimal point to keep",
+			Type:        pipescript.ConstArgType,
+			Optional:    true,
+			Default:     pipescript.MustPipe(pipescript.NewConstTransform(0), nil),
+			Schema: map[string]interface{}{
+				"type":    "integer",
+				"minimum": -15,
+				"maximum": 15,
+			},
+		},
+	},
+	Constructor: pipescript.NewBasic(func(consts []interface{}, pipes []*pipescript.Pipe) ([]interface{}, []*pipescript.Pipe, error) {
+		return []interface{}{math.Pow(10, float64(consts[0].(int64)))}, pipes, nil
+	}, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		if dp.Data == nil {
+			out.Data = nil
+			return out, nil
+		}
+		i, f, isInt, err := mathValue(dp.Data)
+		if err != nil {
+			return nil, err
+		}
+		scale := consts[0].(float64)
+		if isInt && scale >= 1 {
+			// Integers already have no decimal places
+			out.Data = i
+			return out, nil
+		}
+		if math.IsInf(f*scale, 0) {
+			// The number is too large to have any digits after the decimal point
+			out.Data = mathResult(f)
+			return out, nil
+		}
+		out.Data = mathResult(math.Round(f*scale) / scale)
+		return out, nil
+	}),
+}
+
+var Clamp = &pipescript.Transform{
+	Name:         "clamp",
+	Description:  "Limits a number to the range between the two arguments",
+	InputSchema:  numberSchema,
+	OutputSchema: numberSchema,
+	Args: []pipescript.TransformArg{
+		{
+			Description: "The lowest allowed value",
+			Type:        pipescript.TransformArgType,
+			Schema:      numberSchema,
+		},
+		{
+			Description: "The highest allowed value",
+			Type:        pipescript.TransformArgType,
+			Schema:      numberSchema,
+		},
+	},
+	Constructor: pipescript.NewBasic(nil, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		if dp.Data == nil {
+			out.Data = nil
+			return out, nil
+		}
+		i, f, isInt, err := mathValue(dp.Data)
+		if err != nil {
+			return nil, err
+		}
+		out.Data = dp.Data
+		if isInt {
+			out.Data = i
+		}
+		// A null bound does not limit the value
+		lo, hi := math.Inf(-1), math.Inf(1)
+		if args[0].Data != nil {
+			if lo, err = args[0].Float(); err != nil {
+				return nil, err
+			}
+		}
+		if args[1].Data != nil {
+			if hi, err = args[1].Float(); err != nil {
+				return nil, err
+			}
+		}
+		if lo > hi {
+			return nil, fmt.Errorf("clamp: the lower bound %v is greater than the upper bound %v", args[0].Data, args[1].Data)
+		}
+		if f < lo {
+			out.Data = args[0].Data
+		} else if f > hi {
+			out.Data = args[1].Data
+		}
+		return out, nil
+	}),
+}
+
+// newCompareFunc creates a transform returning whichever of its args is preferred by the comparison.
+// Null args are ignored.
+func newCompareFunc(name, description string, prefer func(a, b float64) bool) *pipescript.Transform {
+	return &pipescript.Transform{
+		Name:         name,
+		Description:  description,
+		OutputSchema: numberSchema,
+		Args: []pipescript.TransformArg{
+			{
+				Description: "The first value to compare",
+				Type:        pipescript.TransformArgType,
+				Schema:      numberSchema,
+			},
+			{
+				Description: "The other values to compare",
+				Type:        pipescript.TransformArgType,
+				Schema:      numberSchema,
+				Variadic:    true,
+			},
+		},
+		Constructor: pipescript.NewArgBasic(func(args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+			out.Data = nil
+			cur := float64(0)
+			for _, a := range args {
+				if a.Data == nil {
+					continue
+				}
+				f, err := a.Float()
+				if err != nil {
+					return nil, err
+				}
+				if out.Data == nil || prefer(f, cur) {
+					out.Data = a.Data
+					cur = f
+				}
+			}
+			return out, nil
+		}),
+	}
+}
+
+var (
+	Least = newCompareFunc("least", "Returns the smallest of its arguments. To find the smallest datapoint in a stream, use min.", func(a, b float64) bool {
+		return a < b
+	})
+	Greatest = newCompareFunc("greatest", "Returns the largest of its arguments. To find the largest datapoint in a stream, use max.", func(a, b float64) bool {
+		return a > b
+	})
+)
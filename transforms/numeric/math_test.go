@@ -0,0 +1,281 @@
+package numeric
+
+import (
+	"testing"
+
+	"github.com/heedy/pipescript"
+)
+
+func TestMath(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "abs",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: -2.5},
+			{Timestamp: 2, Data: int64(-3)},
+			{Timestamp: 3, Data: nil},
+			{Timestamp: 4, Data: 4},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 2.5},
+			{Timestamp: 2, Data: int64(3)},
+			{Timestamp: 3, Data: nil},
+			{Timestamp: 4, Data: int64(4)},
+		},
+	}.Run(t)
+
+	pipescript.TestCase{
+		Pipescript: "floor + ceil",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1.5},
+			{Timestamp: 2, Data: -1.5},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: float64(3)},
+			{Timestamp: 2, Data: float64(-3)},
+		},
+	}.Run(t)
+
+	pipescript.TestCase{
+		Pipescript: "sign",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: -0.1},
+			{Timestamp: 2, Data: int64(0)},
+			{Timestamp: 3, Data: 12.0},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: float64(-1)},
+			{Timestamp: 2, Data: int64(0)},
+			{Timestamp: 3, Data: float64(1)},
+		},
+	}.Run(t)
+
+	pipescript.TestCase{
+		Pipescript: "sqrt",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 16.0},
+			{Timestamp: 2, Data: -1.0},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: float64(4)},
+			{Timestamp: 2, Data: nil},
+		},
+	}.Run(t)
+
+	pipescript.TestCase{
+		Pipescript: "log",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1.0},
+			{Timestamp: 2, Data: 0.0},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: float64(0)},
+			{Timestamp: 2, Data: nil},
+		},
+	}.Run(t)
+
+	pipescript.TestCase{
+		Pipescript: "log10",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1000.0},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: float64(3)},
+		},
+	}.Run(t)
+
+	pipescript.TestCase{
+		Pipescript: "exp + cos - sin",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 0.0},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: float64(2)},
+		},
+	}.Run(t)
+
+	pipescript.TestCase{
+		Pipescript: "abs",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "hi"},
+		},
+		OutputError: true,
+	}.Run(t)
+}
+
+func TestRound(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "round",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 3.14159},
+			{Timestamp: 2, Data: 2.5},
+			{Timestamp: 3, Data: -2.5},
+			{Timestamp: 4, Data: int64(7)},
+			{Timestamp: 5, Data: nil},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: float64(3)},
+			{Timestamp: 2, Data: float64(3)},
+			{Timestamp: 3, Data: float64(-3)},
+			{Timestamp: 4, Data: int64(7)},
+			{Timestamp: 5, Data: nil},
+		},
+	}.Run(t)
+
+	pipescript.TestCase{
+		Pipescript: "round(2)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 3.14159},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 3.14},
+		},
+	}.Run(t)
+
+	// Large numbers are kept as they are, rather than overflowing
+	pipescript.TestCase{
+		Pipescript: "round(15)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1e300},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1e300},
+		},
+	}.Run(t)
+
+	pipescript.TestCase{
+		Pipescript: "round(400)",
+		Parsed:     "error",
+	}.Run(t)
+
+	pipescript.TestCase{
+		Pipescript: "round(-1)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: int64(14)},
+			{Timestamp: 2, Data: 15.0},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: float64(10)},
+			{Timestamp: 2, Data: float64(20)},
+		},
+	}.Run(t)
+
+	pipescript.TestCase{
+		Pipescript: "round(1.5)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1.0},
+		},
+		Parsed: "error",
+	}.Run(t)
+}
+
+func TestClamp(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "clamp(0, 10)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: -5.0},
+			{Timestamp: 2, Data: 5.0},
+			{Timestamp: 3, Data: 15.0},
+			{Timestamp: 4, Data: int64(3)},
+			{Timestamp: 5, Data: nil},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: float64(0)},
+			{Timestamp: 2, Data: 5.0},
+			{Timestamp: 3, Data: float64(10)},
+			{Timestamp: 4, Data: int64(3)},
+			{Timestamp: 5, Data: nil},
+		},
+	}.Run(t)
+
+	pipescript.TestCase{
+		Pipescript: "clamp(10, 0)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 5.0},
+		},
+		OutputError: true,
+	}.Run(t)
+
+	pipescript.TestCase{
+		Pipescript: "clamp(null, 2)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: -100.0},
+			{Timestamp: 2, Data: 5.0},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: -100.0},
+			{Timestamp: 2, Data: float64(2)},
+		},
+	}.Run(t)
+}
+
+func TestLeastGreatest(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "least(d('a'), d('b'))",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"a": 1.0, "b": 2.0}},
+			{Timestamp: 2, Data: map[string]interface{}{"a": 3.0, "b": -2.0}},
+			{Timestamp: 3, Data: map[string]interface{}{"a": nil, "b": 5.0}},
+			{Timestamp: 4, Data: map[string]interface{}{"a": nil, "b": nil}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1.0},
+			{Timestamp: 2, Data: -2.0},
+			{Timestamp: 3, Data: 5.0},
+			{Timestamp: 4, Data: nil},
+		},
+	}.Run(t)
+
+	pipescript.TestCase{
+		Pipescript: "greatest(d('a'), d('b'))",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"a": 1.0, "b": 2.0}},
+			{Timestamp: 2, Data: map[string]interface{}{"a": 3.0, "b": -2.0}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 2.0},
+			{Timestamp: 2, Data: 3.0},
+		},
+	}.Run(t)
+
+	pipescript.TestCase{
+		Pipescript: "least(d('a'), d('b'), d('c'), 0)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"a": 1.0, "b": 2.0, "c": -3.0}},
+			{Timestamp: 2, Data: map[string]interface{}{"a": 3.0, "b": 2.0, "c": nil}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: -3.0},
+			{Timestamp: 2, Data: float64(0)},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "least(1)",
+		Parsed:     "error",
+	}.Run(t)
+
+	// Constant arguments are folded at parse time
+	pipescript.TestCase{
+		Pipescript: "greatest(3, 4, 8, 2)",
+		Parsed:     "8",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1.0},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: float64(8)},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "greatest(3, 4)",
+		Parsed:     "4",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1.0},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: float64(4)},
+		},
+	}.Run(t)
+}
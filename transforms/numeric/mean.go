@@ -25,12 +25,15 @@ var Mean = &pipescript.Transform{
 		cursum := float64(0)
 		count := int64(0)
 		for dp != nil {
-			f, err := dp.Float()
-			if err != nil {
-				return nil, err
+			// Null values are skipped
+			if dp.Data != nil {
+				f, err := dp.Float()
+				if err != nil {
+					return nil, err
+				}
+				cursum += f
+				count++
 			}
-			cursum += f
-			count++
 			ldp = dp
 			dp, _, err = e.Next(nil)
 			if err != nil {
@@ -38,7 +41,11 @@ var Mean = &pipescript.Transform{
 			}
 		}
 
-		out.Data = cursum / float64(count)
+		if count == 0 {
+			out.Data = nil
+		} else {
+			out.Data = cursum / float64(count)
+		}
 		out.Duration = ldp.Timestamp + ldp.Duration - out.Timestamp
 		return out, nil
 	}),
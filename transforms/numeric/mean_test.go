@@ -19,4 +19,25 @@ func TestMean(t *testing.T) {
 			{Timestamp: 1, Duration: 2, Data: float64(3)},
 		},
 	}.Run(t)
+
+	pipescript.TestCase{
+		Pipescript: "mean",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1},
+			{Timestamp: 2, Data: nil},
+			{Timestamp: 3, Data: 5},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Duration: 2, Data: float64(3)},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "mean",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: nil},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: nil},
+		},
+	}.Run(t)
 }
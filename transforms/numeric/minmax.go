@@ -18,38 +18,49 @@ var Min = &pipescript.Transform{
 	},
 	Constructor: pipescript.NewAggregator(func(e *pipescript.TransformEnv, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
 		args := make([]*pipescript.Datapoint, 1)
-		dp, args, err := e.Next(args)
-		if err != nil || dp == nil {
-			return nil, err
-		}
-		curmin := dp
-		f, err := args[0].Float()
-		if err != nil {
-			return nil, err
-		}
-		curval := f
+		found := false
+		curval := float64(0)
+		var start, end float64
+		seen := false
 		for {
-			dp, args, err = e.Next(args)
+			dp, args, err := e.Next(args)
 			if err != nil {
 				return nil, err
 			}
 			if dp == nil {
 				break
 			}
+			if !seen {
+				seen = true
+				start = dp.Timestamp
+			}
+			end = dp.Timestamp + dp.Duration
+			// Datapoints with a null value are skipped
+			if args[0].Data == nil {
+				continue
+			}
 			f, err := args[0].Float()
 			if err != nil {
 				return nil, err
 			}
-			if f < curval {
-				curmin = dp
+			if !found || f < curval {
+				found = true
 				curval = f
+				out.Data = dp.Data
+				out.Timestamp = dp.Timestamp
+				out.Duration = dp.Duration
 			}
 
 		}
-
-		out.Data = curmin.Data
-		out.Timestamp = curmin.Timestamp
-		out.Duration = curmin.Duration
+		if !seen {
+			return nil, nil
+		}
+		if !found {
+			// All values were null, so like mean, the result is null
+			out.Timestamp = start
+			out.Duration = end - start
+			out.Data = nil
+		}
 		return out, nil
 	}),
 }
@@ -70,38 +81,49 @@ var Max = &pipescript.Transform{
 	},
 	Constructor: pipescript.NewAggregator(func(e *pipescript.TransformEnv, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
 		args := make([]*pipescript.Datapoint, 1)
-		dp, args, err := e.Next(args)
-		if err != nil || dp == nil {
-			return nil, err
-		}
-		curmax := dp
-		f, err := args[0].Float()
-		if err != nil {
-			return nil, err
-		}
-		curval := f
+		found := false
+		curval := float64(0)
+		var start, end float64
+		seen := false
 		for {
-			dp, args, err = e.Next(args)
+			dp, args, err := e.Next(args)
 			if err != nil {
 				return nil, err
 			}
 			if dp == nil {
 				break
 			}
+			if !seen {
+				seen = true
+				start = dp.Timestamp
+			}
+			end = dp.Timestamp + dp.Duration
+			// Datapoints with a null value are skipped
+			if args[0].Data == nil {
+				continue
+			}
 			f, err := args[0].Float()
 			if err != nil {
 				return nil, err
 			}
-			if f > curval {
-				curmax = dp
+			if !found || f > curval {
+				found = true
 				curval = f
+				out.Data = dp.Data
+				out.Timestamp = dp.Timestamp
+				out.Duration = dp.Duration
 			}
 
 		}
-
-		out.Data = curmax.Data
-		out.Timestamp = curmax.Timestamp
-		out.Duration = curmax.Duration
+		if !seen {
+			return nil, nil
+		}
+		if !found {
+			// All values were null, so like mean, the result is null
+			out.Timestamp = start
+			out.Duration = end - start
+			out.Data = nil
+		}
 		return out, nil
 	}),
 }
@@ -60,4 +60,34 @@ func TestMax(t *testing.T) {
 			{Timestamp: 1, Data: 3},
 		},
 	}.Run(t)
+
+	pipescript.TestCase{
+		Pipescript: "max(d('v'))",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{}},
+			{Timestamp: 2, Data: map[string]interface{}{"v": 1}},
+			{Timestamp: 3, Data: map[string]interface{}{"v": 2}},
+			{Timestamp: 4, Data: map[string]interface{}{}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 3, Data: map[string]interface{}{"v": 2}},
+		},
+	}.Run(t)
+}
+
+func TestMinMaxNull(t *testing.T) {
+	Register()
+	for _, script := range []string{"min", "max", "mean"} {
+		// Like mean, min and max of only null values are null
+		pipescript.TestCase{
+			Pipescript: script,
+			Input: []pipescript.Datapoint{
+				{Timestamp: 1, Data: nil},
+				{Timestamp: 2, Duration: 1, Data: nil},
+			},
+			Output: []pipescript.Datapoint{
+				{Timestamp: 1, Duration: 2, Data: nil},
+			},
+		}.Run(t)
+	}
 }
@@ -0,0 +1,189 @@
+package numeric
+
+import (
+	"errors"
+	"math"
+
+	"github.com/heedy/pipescript"
+	"github.com/heedy/pipescript/resources"
+)
+
+// bivariate holds the means and the sums of squared deviations of pairs of values. It is updated one pair
+// at a time with Welford's method, which stays accurate for large values such as timestamps.
+type bivariate struct {
+	n      float64
+	mx, my float64
+	sxx    float64
+	syy    float64
+	sxy    float64
+}
+
+func (b *bivariate) add(x, y float64) {
+	b.n++
+	dx := x - b.mx
+	b.mx += dx / b.n
+	dy := y - b.my
+	b.my += dy / b.n
+	b.sxx += dx * (x - b.mx)
+	b.syy += dy * (y - b.my)
+	b.sxy += dx * (y - b.my)
+}
+
+// linreg returns the least squares line through the pairs, or nil if the x values are all the same
+func (b *bivariate) linreg() interface{} {
+	if b.n < 2 || b.sxx == 0 {
+		return nil
+	}
+	slope := b.sxy / b.sxx
+	r2 := float64(1)
+	if b.syy != 0 {
+		r2 = b.sxy * b.sxy / (b.sxx * b.syy)
+	}
+	return map[string]interface{}{
+		"slope":     slope,
+		"intercept": b.my - slope*b.mx,
+		"r2":        r2,
+	}
+}
+
+// pairFunc returns the x and y values of a datapoint
+type pairFunc func(dp *pipescript.Datapoint, args []*pipescript.Datapoint) (interface{}, interface{})
+
+func argPair(dp *pipescript.Datapoint, args []*pipescript.Datapoint) (interface{}, interface{}) {
+	return args[0].Data, args[1].Data
+}
+
+// newBivariateAggregator returns an aggregator over the x and y values of each datapoint. Datapoints where either
+// is null are skipped.
+func newBivariateAggregator(pair pairFunc, result func(b *bivariate) interface{}) pipescript.TransformConstructor {
+	return pipescript.NewAggregator(func(e *pipescript.TransformEnv, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		dp, args, err := e.Next(nil)
+		if err != nil || dp == nil {
+			return nil, err
+		}
+		out.Timestamp = dp.Timestamp
+		var b bivariate
+		for dp != nil {
+			xv, yv := pair(dp, args)
+			if xv != nil && yv != nil {
+				x, ok := pipescript.Float(xv)
+				y, ok2 := pipescript.Float(yv)
+				if !ok || !ok2 {
+					return nil, errors.New("Values must be numbers")
+				}
+				b.add(x, y)
+			}
+			out.Duration = dp.Timestamp + dp.Duration - out.Timestamp
+			dp, args, err = e.Next(args)
+			if err != nil {
+				return nil, err
+			}
+		}
+		out.Data = result(&b)
+		return out, nil
+	})
+}
+
+// pairArgs returns the args of a transform of two values of each datapoint
+func pairArgs() []pipescript.TransformArg {
+	return []pipescript.TransformArg{
+		{
+			Name:        "x",
+			Description: "The first value of each datapoint",
+			Type:        pipescript.TransformArgType,
+			Schema: map[string]interface{}{
+				"type": "number",
+			},
+		},
+		{
+			Name:        "y",
+			Description: "The second value of each datapoint",
+			Type:        pipescript.TransformArgType,
+			Schema: map[string]interface{}{
+				"type": "number",
+			},
+		},
+	}
+}
+
+var linregSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"slope": map[string]interface{}{
+			"type": "number",
+		},
+		"intercept": map[string]interface{}{
+			"type": "number",
+		},
+		"r2": map[string]interface{}{
+			"type": "number",
+		},
+	},
+}
+
+var Cov = &pipescript.Transform{
+	Name:          "cov",
+	Description:   "Returns the sample covariance of two values of the datapoints",
+	Documentation: string(resources.MustAsset("docs/transforms/cov.md")),
+	OutputSchema: map[string]interface{}{
+		"type": "number",
+	},
+	Args: pairArgs(),
+	Constructor: newBivariateAggregator(argPair, func(b *bivariate) interface{} {
+		if b.n < 2 {
+			return nil
+		}
+		return b.sxy / (b.n - 1)
+	}),
+}
+
+var Corr = &pipescript.Transform{
+	Name:          "corr",
+	Description:   "Returns the Pearson correlation coefficient of two values of the datapoints",
+	Documentation: string(resources.MustAsset("docs/transforms/corr.md")),
+	OutputSchema: map[string]interface{}{
+		"type": "number",
+	},
+	Args: pairArgs(),
+	Constructor: newBivariateAggregator(argPair, func(b *bivariate) interface{} {
+		if b.n < 2 || b.sxx == 0 || b.syy == 0 {
+			return nil
+		}
+		return b.sxy / math.Sqrt(b.sxx*b.syy)
+	}),
+}
+
+var Linreg = &pipescript.Transform{
+	Name:          "linreg",
+	Description:   "Fits a line to two values of the datapoints, returning its slope, intercept and r2",
+	Documentation: string(resources.MustAsset("docs/transforms/linreg.md")),
+	OutputSchema:  linregSchema,
+	Args:          pairArgs(),
+	Constructor: newBivariateAggregator(argPair, func(b *bivariate) interface{} {
+		return b.linreg()
+	}),
+}
+
+var Trend = &pipescript.Transform{
+	Name:          "trend",
+	Description:   "Fits a line to the data over time, returning its slope per second, intercept and r2",
+	Documentation: string(resources.MustAsset("docs/transforms/trend.md")),
+	OutputSchema:  linregSchema,
+	Args: []pipescript.TransformArg{
+		{
+			Name:        "value",
+			Description: "The value to fit against the timestamp",
+			Type:        pipescript.TransformArgType,
+			Optional:    true,
+			Default:     pipescript.IdentityPipe,
+			Schema: map[string]interface{}{
+				"type": "number",
+			},
+		},
+	},
+	Constructor: newBivariateAggregator(func(dp *pipescript.Datapoint, args []*pipescript.Datapoint) (interface{}, interface{}) {
+		return dp.Timestamp, args[0].Data
+	}, func(b *bivariate) interface{} {
+		return b.linreg()
+	}),
+}
@@ -0,0 +1,135 @@
+package numeric
+
+import (
+	"testing"
+
+	"github.com/heedy/pipescript"
+)
+
+var activity = []pipescript.Datapoint{
+	{Timestamp: 0, Data: map[string]interface{}{"steps": 0, "hr": 62}},
+	{Timestamp: 60, Data: map[string]interface{}{"steps": 40, "hr": 75}},
+	{Timestamp: 120, Data: map[string]interface{}{"steps": 100, "hr": 90}},
+	{Timestamp: 180, Data: map[string]interface{}{"steps": 80, "hr": 88}},
+	{Timestamp: 240, Data: map[string]interface{}{"steps": 10, "hr": 70}},
+}
+
+func TestCorrCov(t *testing.T) {
+	Corr.Register()
+	Cov.Register()
+	pipescript.TestCase{
+		Pipescript: "corr(d('steps'), d('hr'))",
+		Input:      activity,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 0, Duration: 240, Data: 0.9822294463851707},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "cov(d('steps'), d('hr'))",
+		Input:      activity,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 0, Duration: 240, Data: 507.4999999999999},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "corr(d, -2*d)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1},
+			{Timestamp: 2, Data: nil},
+			{Timestamp: 3, Data: 5},
+			{Timestamp: 4, Data: 2},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Duration: 3, Data: float64(-1)},
+		},
+	}.Run(t)
+
+	// The correlation is not defined without variation
+	pipescript.TestCase{
+		Pipescript: "corr(d, 3)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1},
+			{Timestamp: 2, Data: 2},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Duration: 1, Data: nil},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "cov(d, d)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: nil},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "cov(d, d)",
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "cov(d, d)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "hi"},
+		},
+		OutputError: true,
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "cov(d)",
+		Parsed:     "error",
+	}.Run(t)
+}
+
+func TestLinreg(t *testing.T) {
+	Linreg.Register()
+	Trend.Register()
+	pipescript.TestCase{
+		Pipescript: "linreg(d('steps'), d('hr'))",
+		Input:      activity,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 0, Duration: 240, Data: map[string]interface{}{
+				"intercept": 64.58244680851064,
+				"r2":        0.9647746853461191,
+				"slope":     0.2699468085106383,
+			}},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "trend(d('hr'))",
+		Input:      activity,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 0, Duration: 240, Data: map[string]interface{}{
+				"intercept": 71.2,
+				"r2":        0.1480633802816901,
+				"slope":     0.04833333333333332,
+			}},
+		},
+	}.Run(t)
+
+	// The updates stay accurate with large timestamps
+	pipescript.TestCase{
+		Pipescript: "trend",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1.7e9, Data: 1},
+			{Timestamp: 1.7e9 + 1, Data: 3},
+			{Timestamp: 1.7e9 + 2, Data: 5},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1.7e9, Duration: 2, Data: map[string]interface{}{
+				"intercept": float64(-3.4e9 + 1),
+				"r2":        float64(1),
+				"slope":     float64(2),
+			}},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "linreg(1, d)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 1},
+			{Timestamp: 2, Data: 2},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Duration: 1, Data: nil},
+		},
+	}.Run(t)
+}
@@ -30,11 +30,14 @@ var Sum = &pipescript.Transform{
 		ldp := dp
 
 		for dp != nil {
-			f, err := dp.Float()
-			if err != nil {
-				return nil, err
+			// Null values are skipped
+			if dp.Data != nil {
+				f, err := dp.Float()
+				if err != nil {
+					return nil, err
+				}
+				cursum += f
 			}
-			cursum += f
 
 			dp, _, err = e.Next(nil)
 			if err != nil {
@@ -0,0 +1,111 @@
+package objects
+
+import (
+	"sort"
+
+	"github.com/heedy/pipescript"
+)
+
+// sortedKeys returns the keys of the object in sorted order, so that the output is the same
+// on every run
+func sortedKeys(obj map[string]interface{}) []string {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var Keys = &pipescript.Transform{
+	Name:        "keys",
+	Description: "Returns a sorted array of the keys of an object",
+	InputSchema: map[string]interface{}{
+		"type": "object",
+	},
+	OutputSchema: map[string]interface{}{
+		"type": "array",
+		"items": map[string]interface{}{
+			"type": "string",
+		},
+	},
+	Constructor: pipescript.NewBasic(nil, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		if dp.Data == nil {
+			out.Data = nil
+			return out, nil
+		}
+		obj, ok := dp.Data.(map[string]interface{})
+		if !ok {
+			return nil, errNotObject
+		}
+		res := make([]interface{}, 0, len(obj))
+		for _, k := range sortedKeys(obj) {
+			res = append(res, k)
+		}
+		out.Data = res
+		return out, nil
+	}),
+}
+
+var Values = &pipescript.Transform{
+	Name:        "values",
+	Description: "Returns an array of the values of an object, ordered by their keys",
+	InputSchema: map[string]interface{}{
+		"type": "object",
+	},
+	OutputSchema: map[string]interface{}{
+		"type": "array",
+	},
+	Constructor: pipescript.NewBasic(nil, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		if dp.Data == nil {
+			out.Data = nil
+			return out, nil
+		}
+		obj, ok := dp.Data.(map[string]interface{})
+		if !ok {
+			return nil, errNotObject
+		}
+		res := make([]interface{}, 0, len(obj))
+		for _, k := range sortedKeys(obj) {
+			res = append(res, obj[k])
+		}
+		out.Data = res
+		return out, nil
+	}),
+}
+
+var Entries = &pipescript.Transform{
+	Name:        "entries",
+	Description: "Returns an array of {\"key\": key, \"value\": value} objects for each field of an object, ordered by key",
+	InputSchema: map[string]interface{}{
+		"type": "object",
+	},
+	OutputSchema: map[string]interface{}{
+		"type": "array",
+		"items": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"key": map[string]interface{}{
+					"type": "string",
+				},
+				"value": map[string]interface{}{},
+			},
+		},
+	},
+	Constructor: pipescript.NewBasic(nil, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		if dp.Data == nil {
+			out.Data = nil
+			return out, nil
+		}
+		obj, ok := dp.Data.(map[string]interface{})
+		if !ok {
+			return nil, errNotObject
+		}
+		res := make([]interface{}, 0, len(obj))
+		for _, k := range sortedKeys(obj) {
+			res = append(res, map[string]interface{}{"key": k, "value": obj[k]})
+		}
+		out.Data = res
+		return out, nil
+	}),
+}
@@ -0,0 +1,54 @@
+package objects
+
+import (
+	"testing"
+
+	"github.com/heedy/pipescript"
+)
+
+func TestKeysValues(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "keys",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"b": 1, "a": 2}},
+			{Timestamp: 2, Data: map[string]interface{}{}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: []interface{}{"a", "b"}},
+			{Timestamp: 2, Data: []interface{}{}},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "values",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"b": 1, "a": 2}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: []interface{}{2, 1}},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "keys",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "hi"},
+		},
+		OutputError: true,
+	}.Run(t)
+}
+
+func TestEntries(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "entries",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"b": 1, "a": 2}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: []interface{}{
+				map[string]interface{}{"key": "a", "value": 2},
+				map[string]interface{}{"key": "b", "value": 1},
+			}},
+		},
+	}.Run(t)
+}
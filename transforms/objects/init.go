@@ -0,0 +1,17 @@
+/*
+Package objects contains transforms that modify the fields of object data, without needing to rebuild the object by hand
+*/
+package objects
+
+func Register() {
+	Set.Register()
+	Unset.Register()
+	Pick.Register()
+	Omit.Register()
+	Rename.Register()
+	Merge.Register()
+
+	Keys.Register()
+	Values.Register()
+	Entries.Register()
+}
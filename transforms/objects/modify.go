@@ -0,0 +1,284 @@
+package objects
+
+import (
+	"errors"
+
+	"github.com/heedy/pipescript"
+	"github.com/heedy/pipescript/resources"
+)
+
+var errNotObject = errors.New("Object transforms can only be used on objects")
+
+// copyObject returns a shallow copy of the datapoint's object. The datapoint itself must not be
+// modified, since it might be shared with other transforms reading the same stream.
+func copyObject(dp *pipescript.Datapoint) (map[string]interface{}, error) {
+	obj, ok := dp.Data.(map[string]interface{})
+	if !ok {
+		return nil, errNotObject
+	}
+	res := make(map[string]interface{}, len(obj))
+	for k, v := range obj {
+		res[k] = v
+	}
+	return res, nil
+}
+
+// constKeys reads the key (or array of keys) given in the first const arg as a slice of strings
+func constKeys(consts []interface{}, pipes []*pipescript.Pipe) ([]interface{}, []*pipescript.Pipe, error) {
+	keys := make([]string, 0, len(consts))
+	for _, c := range consts {
+		if s, ok := c.(string); ok {
+			keys = append(keys, s)
+			continue
+		}
+		arr, ok := c.([]interface{})
+		if !ok {
+			return nil, nil, errors.New("keys must be strings or arrays of strings")
+		}
+		for i := range arr {
+			s, ok := arr[i].(string)
+			if !ok {
+				return nil, nil, errors.New("keys must be strings or arrays of strings")
+			}
+			keys = append(keys, s)
+		}
+	}
+	return []interface{}{keys}, pipes, nil
+}
+
+var keysArg = pipescript.TransformArg{
+	Description: "The keys, given either as separate args or as an array",
+	Type:        pipescript.ConstArgType,
+	Variadic:    true,
+	Schema: map[string]interface{}{
+		"oneOf": []interface{}{
+			map[string]interface{}{
+				"type": "string",
+			},
+			map[string]interface{}{
+				"type": "array",
+				"items": map[string]interface{}{
+					"type": "string",
+				},
+			},
+		},
+	},
+}
+
+var Set = &pipescript.Transform{
+	Name:          "set",
+	Description:   "Sets the given key of an object to the value of the second argument, keeping all other fields",
+	Documentation: string(resources.MustAsset("docs/transforms/set.md")),
+	InputSchema: map[string]interface{}{
+		"type": "object",
+	},
+	OutputSchema: map[string]interface{}{
+		"type": "object",
+	},
+	Args: []pipescript.TransformArg{
+		{
+			Description: "The key to set",
+			Type:        pipescript.ConstArgType,
+			Schema: map[string]interface{}{
+				"type": "string",
+			},
+		},
+		{
+			Description: "The value to set the key to",
+			Type:        pipescript.TransformArgType,
+		},
+	},
+	Constructor: pipescript.NewBasic(nil, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		if dp.Data == nil {
+			out.Data = nil
+			return out, nil
+		}
+		obj, err := copyObject(dp)
+		if err != nil {
+			return nil, err
+		}
+		obj[consts[0].(string)] = args[0].Data
+		out.Data = obj
+		return out, nil
+	}),
+}
+
+var Unset = &pipescript.Transform{
+	Name:        "unset",
+	Description: "Removes the given key from an object",
+	InputSchema: map[string]interface{}{
+		"type": "object",
+	},
+	OutputSchema: map[string]interface{}{
+		"type": "object",
+	},
+	Args: []pipescript.TransformArg{
+		{
+			Description: "The key to remove",
+			Type:        pipescript.ConstArgType,
+			Schema: map[string]interface{}{
+				"type": "string",
+			},
+		},
+	},
+	Constructor: pipescript.NewBasic(nil, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		if dp.Data == nil {
+			out.Data = nil
+			return out, nil
+		}
+		obj, err := copyObject(dp)
+		if err != nil {
+			return nil, err
+		}
+		delete(obj, consts[0].(string))
+		out.Data = obj
+		return out, nil
+	}),
+}
+
+var Pick = &pipescript.Transform{
+	Name:          "pick",
+	Description:   "Returns an object with only the given keys",
+	Documentation: string(resources.MustAsset("docs/transforms/pick.md")),
+	InputSchema: map[string]interface{}{
+		"type": "object",
+	},
+	OutputSchema: map[string]interface{}{
+		"type": "object",
+	},
+	Args: []pipescript.TransformArg{
+		keysArg,
+	},
+	Constructor: pipescript.NewBasic(constKeys, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		if dp.Data == nil {
+			out.Data = nil
+			return out, nil
+		}
+		obj, ok := dp.Data.(map[string]interface{})
+		if !ok {
+			return nil, errNotObject
+		}
+		keys := consts[0].([]string)
+		res := make(map[string]interface{}, len(keys))
+		for _, k := range keys {
+			if v, ok := obj[k]; ok {
+				res[k] = v
+			}
+		}
+		out.Data = res
+		return out, nil
+	}),
+}
+
+var Omit = &pipescript.Transform{
+	Name:        "omit",
+	Description: "Returns an object with the given keys removed",
+	InputSchema: map[string]interface{}{
+		"type": "object",
+	},
+	OutputSchema: map[string]interface{}{
+		"type": "object",
+	},
+	Args: []pipescript.TransformArg{
+		keysArg,
+	},
+	Constructor: pipescript.NewBasic(constKeys, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		if dp.Data == nil {
+			out.Data = nil
+			return out, nil
+		}
+		obj, err := copyObject(dp)
+		if err != nil {
+			return nil, err
+		}
+		for _, k := range consts[0].([]string) {
+			delete(obj, k)
+		}
+		out.Data = obj
+		return out, nil
+	}),
+}
+
+var Rename = &pipescript.Transform{
+	Name:        "rename",
+	Description: "Renames a key of an object, keeping its value",
+	InputSchema: map[string]interface{}{
+		"type": "object",
+	},
+	OutputSchema: map[string]interface{}{
+		"type": "object",
+	},
+	Args: []pipescript.TransformArg{
+		{
+			Description: "The key to rename",
+			Type:        pipescript.ConstArgType,
+			Schema: map[string]interface{}{
+				"type": "string",
+			},
+		},
+		{
+			Description: "The new name of the key",
+			Type:        pipescript.ConstArgType,
+			Schema: map[string]interface{}{
+				"type": "string",
+			},
+		},
+	},
+	Constructor: pipescript.NewBasic(nil, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		if dp.Data == nil {
+			out.Data = nil
+			return out, nil
+		}
+		obj, err := copyObject(dp)
+		if err != nil {
+			return nil, err
+		}
+		if v, ok := obj[consts[0].(string)]; ok {
+			delete(obj, consts[0].(string))
+			obj[consts[1].(string)] = v
+		}
+		out.Data = obj
+		return out, nil
+	}),
+}
+
+var Merge = &pipescript.Transform{
+	Name:        "merge",
+	Description: "Adds the fields of the object given in the argument to the datapoint's object, replacing fields that already exist",
+	InputSchema: map[string]interface{}{
+		"type": "object",
+	},
+	OutputSchema: map[string]interface{}{
+		"type": "object",
+	},
+	Args: []pipescript.TransformArg{
+		{
+			Description: "The object whose fields to add",
+			Type:        pipescript.TransformArgType,
+			Schema: map[string]interface{}{
+				"type": "object",
+			},
+		},
+	},
+	Constructor: pipescript.NewBasic(nil, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		if dp.Data == nil {
+			out.Data = nil
+			return out, nil
+		}
+		obj, err := copyObject(dp)
+		if err != nil {
+			return nil, err
+		}
+		if args[0].Data != nil {
+			other, ok := args[0].Data.(map[string]interface{})
+			if !ok {
+				return nil, errors.New("merge requires an object argument")
+			}
+			for k, v := range other {
+				obj[k] = v
+			}
+		}
+		out.Data = obj
+		return out, nil
+	}),
+}
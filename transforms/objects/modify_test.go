@@ -0,0 +1,140 @@
+package objects
+
+import (
+	"testing"
+
+	"github.com/heedy/pipescript"
+)
+
+func TestSet(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "set(1, 2)",
+		Parsed:     "error",
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "set('steps', d('steps') * 2)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"steps": 10, "device": "phone"}},
+			{Timestamp: 2, Data: map[string]interface{}{"device": "phone"}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"steps": float64(20), "device": "phone"}},
+			{Timestamp: 2, Data: map[string]interface{}{"steps": nil, "device": "phone"}},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "set('a', 1)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: nil},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: nil},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "set('a', 1)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 4},
+		},
+		OutputError: true,
+	}.Run(t)
+}
+
+func TestUnset(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "unset('device')",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"steps": 10, "device": "phone"}},
+			{Timestamp: 2, Data: map[string]interface{}{"steps": 10}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"steps": 10}},
+			{Timestamp: 2, Data: map[string]interface{}{"steps": 10}},
+		},
+	}.Run(t)
+}
+
+func TestPickOmit(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "pick(1)",
+		Parsed:     "error",
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "pick('steps')",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"steps": 10, "device": "phone"}},
+			{Timestamp: 2, Data: map[string]interface{}{"device": "phone"}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"steps": 10}},
+			{Timestamp: 2, Data: map[string]interface{}{}},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "pick('steps', 'battery')",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"steps": 10, "device": "phone", "battery": 0.5}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"steps": 10, "battery": 0.5}},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "pick(['steps', 'battery'])",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"steps": 10, "device": "phone", "battery": 0.5}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"steps": 10, "battery": 0.5}},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "omit('steps', 'battery')",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"steps": 10, "device": "phone", "battery": 0.5}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"device": "phone"}},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "omit('steps')",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"steps": 10, "device": "phone"}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"device": "phone"}},
+		},
+	}.Run(t)
+}
+
+func TestRename(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "rename('steps', 'count')",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"steps": 10, "device": "phone"}},
+			{Timestamp: 2, Data: map[string]interface{}{"device": "phone"}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"count": 10, "device": "phone"}},
+			{Timestamp: 2, Data: map[string]interface{}{"device": "phone"}},
+		},
+	}.Run(t)
+}
+
+func TestMerge(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "merge({'source': 'fitbit', 'steps': d('steps') + 1})",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"steps": 10, "device": "phone"}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"steps": float64(11), "device": "phone", "source": "fitbit"}},
+		},
+	}.Run(t)
+}
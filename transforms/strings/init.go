@@ -7,8 +7,17 @@ func Register() {
 
 	Wc.Register()
 	Regex.Register()
+	RegexExtract.Register()
+	RegexReplace.Register()
+	RegexFindall.Register()
+	RegexParse.Register()
 	Contains.Register()
 	Startswith.Register()
 	Endswith.Register()
 	Urldomain.Register()
+	Tokens.Register()
+	Ngrams.Register()
+	Wordfreq.Register()
+	Levenshtein.Register()
+	Similarity.Register()
 }
@@ -2,12 +2,39 @@ package strings
 
 import (
 	"errors"
+	"fmt"
 	"regexp"
 
 	"github.com/heedy/pipescript"
 	"github.com/heedy/pipescript/resources"
 )
 
+// constRegex compiles the regular expression given in the first const arg, so that
+// it is only compiled once when the transform is constructed. The remaining consts are passed through.
+func constRegex(consts []interface{}, pipes []*pipescript.Pipe) ([]interface{}, []*pipescript.Pipe, error) {
+	c2 := make([]interface{}, len(consts))
+	c, ok := pipescript.String(consts[0])
+	if !ok {
+		return nil, nil, errors.New("regex must be a string")
+	}
+	re, err := regexp.Compile(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	c2[0] = re
+	copy(c2[1:], consts[1:])
+	return c2, pipes, nil
+}
+
+var regexArg = pipescript.TransformArg{
+	Name:        "pattern",
+	Description: "The regular expression to use",
+	Type:        pipescript.ConstArgType,
+	Schema: map[string]interface{}{
+		"type": "string",
+	},
+}
+
 var Regex = &pipescript.Transform{
 	Name:          "regex",
 	Description:   "Returns true if the given regular expression matches the data string",
@@ -19,8 +46,99 @@ var Regex = &pipescript.Transform{
 		"type": "boolean",
 	},
 	Args: []pipescript.TransformArg{
+		regexArg,
+	},
+	Constructor: pipescript.NewBasic(constRegex, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		d, err := dp.String()
+		if err != nil {
+			out.Data = false
+			return out, nil
+		}
+		out.Data = consts[0].(*regexp.Regexp).MatchString(d)
+		return out, nil
+	}),
+}
+
+var RegexExtract = &pipescript.Transform{
+	Name:          "regex_extract",
+	Description:   "Returns the given capture group of the first regular expression match in the data string, or null if there is no match",
+	Documentation: string(resources.MustAsset("docs/transforms/regex_extract.md")),
+	InputSchema: map[string]interface{}{
+		"type": "string",
+	},
+	OutputSchema: map[string]interface{}{
+		"type": []string{"string", "null"},
+	},
+	Args: []pipescript.TransformArg{
+		regexArg,
+		{
+			Name:        "group",
+			Description: "The capture group to return, either by index or by name. 0 is the full match.",
+			Type:        pipescript.ConstArgType,
+			Optional:    true,
+			Default:     pipescript.MustPipe(pipescript.NewConstTransform(0), nil),
+			Schema: map[string]interface{}{
+				"oneOf": []interface{}{
+					map[string]interface{}{
+						"type": "string",
+					},
+					map[string]interface{}{
+						"type": "integer",
+					},
+				},
+			},
+		},
+	},
+	Constructor: pipescript.NewBasic(func(consts []interface{}, pipes []*pipescript.Pipe) ([]interface{}, []*pipescript.Pipe, error) {
+		consts, pipes, err := constRegex(consts, pipes)
+		if err != nil {
+			return nil, nil, err
+		}
+		re := consts[0].(*regexp.Regexp)
+		if name, ok := consts[1].(string); ok {
+			for i, n := range re.SubexpNames() {
+				if n == name && name != "" {
+					consts[1] = i
+					return consts, pipes, nil
+				}
+			}
+			return nil, nil, fmt.Errorf("regex has no capture group named '%s'", name)
+		}
+		idx, ok := pipescript.Int(consts[1])
+		if !ok || idx < 0 || int(idx) > re.NumSubexp() {
+			return nil, nil, fmt.Errorf("regex has no capture group %v", consts[1])
+		}
+		consts[1] = int(idx)
+		return consts, pipes, nil
+	}, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		out.Data = nil
+		d, err := dp.String()
+		if err != nil {
+			return out, nil
+		}
+		m := consts[0].(*regexp.Regexp).FindStringSubmatchIndex(d)
+		idx := consts[1].(int)
+		if m != nil && m[2*idx] >= 0 {
+			out.Data = d[m[2*idx]:m[2*idx+1]]
+		}
+		return out, nil
+	}),
+}
+
+var RegexReplace = &pipescript.Transform{
+	Name:          "regex_replace",
+	Description:   "Replaces all matches of the regular expression in the data string with the replacement string",
+	Documentation: string(resources.MustAsset("docs/transforms/regex_replace.md")),
+	InputSchema: map[string]interface{}{
+		"type": "string",
+	},
+	OutputSchema: map[string]interface{}{
+		"type": "string",
+	},
+	Args: []pipescript.TransformArg{
+		regexArg,
 		{
-			Description: "The regular expression to use",
+			Description: "The replacement string. $1 or ${name} can be used to insert capture groups.",
 			Type:        pipescript.ConstArgType,
 			Schema: map[string]interface{}{
 				"type": "string",
@@ -28,26 +146,98 @@ var Regex = &pipescript.Transform{
 		},
 	},
 	Constructor: pipescript.NewBasic(func(consts []interface{}, pipes []*pipescript.Pipe) ([]interface{}, []*pipescript.Pipe, error) {
-		c2 := make([]interface{}, 1)
-		c, ok := pipescript.String(consts[0])
-		if !ok {
-			return nil, nil, errors.New("regex must be a string")
+		if _, ok := pipescript.String(consts[1]); !ok {
+			return nil, nil, errors.New("regex replacement must be a string")
 		}
-		_, err := regexp.Compile(c)
+		return constRegex(consts, pipes)
+	}, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		d, err := dp.String()
 		if err != nil {
-			return nil, nil, err
+			// Non-string data has nothing to replace
+			out.Data = dp.Data
+			return out, nil
+		}
+		out.Data = consts[0].(*regexp.Regexp).ReplaceAllString(d, consts[1].(string))
+		return out, nil
+	}),
+}
+
+var RegexFindall = &pipescript.Transform{
+	Name:          "regex_findall",
+	Description:   "Returns an array of all matches of the regular expression in the data string",
+	Documentation: string(resources.MustAsset("docs/transforms/regex_findall.md")),
+	InputSchema: map[string]interface{}{
+		"type": "string",
+	},
+	OutputSchema: map[string]interface{}{
+		"type": "array",
+		"items": map[string]interface{}{
+			"type": "string",
+		},
+	},
+	Args: []pipescript.TransformArg{
+		regexArg,
+	},
+	Constructor: pipescript.NewBasic(constRegex, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		res := make([]interface{}, 0)
+		d, err := dp.String()
+		if err == nil {
+			for _, m := range consts[0].(*regexp.Regexp).FindAllString(d, -1) {
+				res = append(res, m)
+			}
 		}
-		c2[0] = c
-		return c2, pipes, nil
+		out.Data = res
+		return out, nil
+	}),
+}
 
+var RegexParse = &pipescript.Transform{
+	Name:          "regex_parse",
+	Description:   "Returns an object of the named capture groups of the regular expression matched against the data string, or null if there is no match",
+	Documentation: string(resources.MustAsset("docs/transforms/regex_parse.md")),
+	InputSchema: map[string]interface{}{
+		"type": "string",
+	},
+	OutputSchema: map[string]interface{}{
+		"type": []string{"object", "null"},
+	},
+	Args: []pipescript.TransformArg{
+		regexArg,
+	},
+	Constructor: pipescript.NewBasic(func(consts []interface{}, pipes []*pipescript.Pipe) ([]interface{}, []*pipescript.Pipe, error) {
+		consts, pipes, err := constRegex(consts, pipes)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, name := range consts[0].(*regexp.Regexp).SubexpNames() {
+			if name != "" {
+				return consts, pipes, nil
+			}
+		}
+		return nil, nil, errors.New("regex_parse requires a regex with named capture groups, such as (?P<name>...)")
 	}, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		out.Data = nil
 		d, err := dp.String()
 		if err != nil {
-			out.Data = false
 			return out, nil
 		}
-		re, _ := regexp.Compile(consts[0].(string))
-		out.Data = re.MatchString(d)
+		re := consts[0].(*regexp.Regexp)
+		m := re.FindStringSubmatchIndex(d)
+		if m == nil {
+			return out, nil
+		}
+		res := make(map[string]interface{})
+		for i, name := range re.SubexpNames() {
+			if name == "" {
+				continue
+			}
+			if m[2*i] >= 0 {
+				res[name] = d[m[2*i]:m[2*i+1]]
+			} else {
+				res[name] = nil
+			}
+		}
+		out.Data = res
 		return out, nil
 	}),
 }
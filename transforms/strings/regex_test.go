@@ -22,3 +22,97 @@ func TestRegex(t *testing.T) {
 		},
 	}.Run(t)
 }
+
+func TestRegexExtract(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "regex_extract('(')",
+		Parsed:     "error",
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "regex_extract('a(b)', 2)",
+		Parsed:     "error",
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "regex_extract('user=(\\w+)', 1)",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "user=alice id=12"},
+			{Timestamp: 2, Data: "nothing"},
+			{Timestamp: 3, Data: 34},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "alice"},
+			{Timestamp: 2, Data: nil},
+			{Timestamp: 3, Data: nil},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "regex_extract('id=(?P<id>[0-9]+)', 'id')",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "user=alice id=12"},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "12"},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "regex_extract('[0-9]+')",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "abc 123 456"},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "123"},
+		},
+	}.Run(t)
+}
+
+func TestRegexReplace(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "regex_replace('([0-9]+)-([0-9]+)', '$2-$1')",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "10-20 and 3-4"},
+			{Timestamp: 2, Data: 34},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "20-10 and 4-3"},
+			{Timestamp: 2, Data: 34},
+		},
+	}.Run(t)
+}
+
+func TestRegexFindall(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "regex_findall('#\\w+')",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "#run with #friends"},
+			{Timestamp: 2, Data: "no tags"},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: []interface{}{"#run", "#friends"}},
+			{Timestamp: 2, Data: []interface{}{}},
+		},
+	}.Run(t)
+}
+
+func TestRegexParse(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "regex_parse('[a-z]+')",
+		Parsed:     "error",
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "regex_parse('(?P<method>[A-Z]+) (?P<path>\\S+)( (?P<status>[0-9]+))?')",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "GET /index.html 200"},
+			{Timestamp: 2, Data: "POST /login"},
+			{Timestamp: 3, Data: "garbage"},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"method": "GET", "path": "/index.html", "status": "200"}},
+			{Timestamp: 2, Data: map[string]interface{}{"method": "POST", "path": "/login", "status": nil}},
+			{Timestamp: 3, Data: nil},
+		},
+	}.Run(t)
+}
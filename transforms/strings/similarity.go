@@ -0,0 +1,125 @@
+package strings
+
+import (
+	"errors"
+
+	"github.com/heedy/pipescript"
+	"github.com/heedy/pipescript/resources"
+)
+
+// levenshtein returns the minimum number of single character insertions, deletions and substitutions
+// needed to change a into b
+func levenshtein(a, b []rune) int {
+	row := make([]int, len(b)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		diag := row[0]
+		row[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			next := diag + cost
+			if row[j]+1 < next {
+				next = row[j] + 1
+			}
+			if row[j-1]+1 < next {
+				next = row[j-1] + 1
+			}
+			diag, row[j] = row[j], next
+		}
+	}
+	return row[len(b)]
+}
+
+// pairArgs returns the args of a transform comparing two strings
+func pairArgs() []pipescript.TransformArg {
+	return []pipescript.TransformArg{
+		{
+			Name:        "other",
+			Description: "The string to compare with, which can be a constant or a field of the datapoint",
+			Type:        pipescript.TransformArgType,
+			Schema: map[string]interface{}{
+				"type": "string",
+			},
+		},
+		{
+			Name:        "value",
+			Description: "The string that is compared, which is the datapoint's data by default",
+			Type:        pipescript.TransformArgType,
+			Optional:    true,
+			Default:     pipescript.IdentityPipe,
+			Schema: map[string]interface{}{
+				"type": "string",
+			},
+		},
+	}
+}
+
+// stringPair returns the value and other strings as runes. If either is null, ok is false.
+func stringPair(args []*pipescript.Datapoint) (a []rune, b []rune, ok bool, err error) {
+	if args[0].Data == nil || args[1].Data == nil {
+		return nil, nil, false, nil
+	}
+	s1, ok1 := pipescript.String(args[1].Data)
+	s2, ok2 := pipescript.String(args[0].Data)
+	if !ok1 || !ok2 {
+		return nil, nil, false, errors.New("Can only compare strings")
+	}
+	return []rune(s1), []rune(s2), true, nil
+}
+
+var Levenshtein = &pipescript.Transform{
+	Name:          "levenshtein",
+	Description:   "Returns the number of characters that need to be inserted, deleted or replaced to change the string into the other string",
+	Documentation: string(resources.MustAsset("docs/transforms/levenshtein.md")),
+	OutputSchema: map[string]interface{}{
+		"type": "integer",
+	},
+	Args: pairArgs(),
+	Constructor: pipescript.NewBasic(nil, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		a, b, ok, err := stringPair(args)
+		if err != nil {
+			return nil, err
+		}
+		out.Data = nil
+		if ok {
+			out.Data = int64(levenshtein(a, b))
+		}
+		return out, nil
+	}),
+}
+
+var Similarity = &pipescript.Transform{
+	Name:          "similarity",
+	Description:   "Returns how similar the string is to the other string, from 0 for completely different strings to 1 for equal strings",
+	Documentation: string(resources.MustAsset("docs/transforms/similarity.md")),
+	OutputSchema: map[string]interface{}{
+		"type":    "number",
+		"minimum": 0,
+		"maximum": 1,
+	},
+	Args: pairArgs(),
+	Constructor: pipescript.NewBasic(nil, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		a, b, ok, err := stringPair(args)
+		if err != nil {
+			return nil, err
+		}
+		out.Data = nil
+		if ok {
+			n := len(a)
+			if len(b) > n {
+				n = len(b)
+			}
+			if n == 0 {
+				out.Data = float64(1)
+			} else {
+				out.Data = 1 - float64(levenshtein(a, b))/float64(n)
+			}
+		}
+		return out, nil
+	}),
+}
@@ -0,0 +1,74 @@
+package strings
+
+import (
+	"testing"
+
+	"github.com/heedy/pipescript"
+)
+
+func TestLevenshtein(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "levenshtein('kitten')",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "kitten"},
+			{Timestamp: 2, Data: "sitting"},
+			{Timestamp: 3, Data: ""},
+			{Timestamp: 4, Data: nil},
+			{Timestamp: 5, Data: "kätten"},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: int64(0)},
+			{Timestamp: 2, Data: int64(3)},
+			{Timestamp: 3, Data: int64(6)},
+			{Timestamp: 4, Data: nil},
+			{Timestamp: 5, Data: int64(1)},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "levenshtein(d(\"b\"), d(\"a\"))",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: map[string]interface{}{"a": "flaw", "b": "lawn"}},
+			{Timestamp: 2, Data: map[string]interface{}{"a": "flaw", "b": nil}},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: int64(2)},
+			{Timestamp: 2, Data: nil},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "levenshtein('kitten')",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 3},
+		},
+		OutputError: true,
+	}.Run(t)
+}
+
+func TestSimilarity(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "similarity('kitten')",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: "kitten"},
+			{Timestamp: 2, Data: "mitten"},
+			{Timestamp: 3, Data: "sitting"},
+			{Timestamp: 4, Data: "dog"},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: float64(1)},
+			{Timestamp: 2, Data: 0.8333333333333334},
+			{Timestamp: 3, Data: 0.5714285714285714},
+			{Timestamp: 4, Data: float64(0)},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "similarity('')",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: ""},
+		},
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: float64(1)},
+		},
+	}.Run(t)
+}
@@ -0,0 +1,223 @@
+package strings
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+
+	"github.com/heedy/pipescript"
+	"github.com/heedy/pipescript/resources"
+)
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.IsMark(r)
+}
+
+// tokenize splits the string into words, which are runs of letters and numbers in any language.
+// Apostrophes between letters are kept, so that "don't" is a single word.
+func tokenize(s string, lowercase bool, removeStopwords bool) []string {
+	r := []rune(s)
+	var res []string
+	var cur []rune
+	end := func() {
+		if len(cur) == 0 {
+			return
+		}
+		w := string(cur)
+		cur = cur[:0]
+		if lowercase {
+			w = strings.ToLower(w)
+		}
+		if removeStopwords && stopwords[strings.ToLower(w)] {
+			return
+		}
+		res = append(res, w)
+	}
+	for i, c := range r {
+		switch {
+		case isWordRune(c):
+			cur = append(cur, c)
+		case (c == '\'' || c == '’') && len(cur) > 0 && i+1 < len(r) && unicode.IsLetter(r[i+1]):
+			cur = append(cur, '\'')
+		default:
+			end()
+		}
+	}
+	end()
+	return res
+}
+
+// textTokens returns the words of a datapoint's data, which is either a string to split into lowercase words,
+// or an array of words, such as the output of tokens
+func textTokens(v interface{}) ([]string, error) {
+	switch d := v.(type) {
+	case string:
+		return tokenize(d, true, false), nil
+	case []interface{}:
+		res := make([]string, len(d))
+		for i := range d {
+			s, ok := pipescript.String(d[i])
+			if !ok {
+				return nil, errors.New("The array must only contain strings")
+			}
+			res[i] = s
+		}
+		return res, nil
+	}
+	return nil, errors.New("Data must be a string or an array of strings")
+}
+
+var textSchema = map[string]interface{}{
+	"oneOf": []interface{}{
+		map[string]interface{}{
+			"type": "string",
+		},
+		map[string]interface{}{
+			"type": "array",
+			"items": map[string]interface{}{
+				"type": "string",
+			},
+		},
+	},
+}
+
+var Tokens = &pipescript.Transform{
+	Name:          "tokens",
+	Description:   "Splits the string into an array of words",
+	Documentation: string(resources.MustAsset("docs/transforms/tokens.md")),
+	InputSchema: map[string]interface{}{
+		"type": "string",
+	},
+	OutputSchema: map[string]interface{}{
+		"type": "array",
+		"items": map[string]interface{}{
+			"type": "string",
+		},
+	},
+	Args: []pipescript.TransformArg{
+		{
+			Name:        "lowercase",
+			Description: "Whether to convert the words to lowercase",
+			Type:        pipescript.ConstArgType,
+			Optional:    true,
+			Schema: map[string]interface{}{
+				"type":    "boolean",
+				"default": true,
+			},
+		},
+		{
+			Name:        "stopwords",
+			Description: "Whether to remove common English words such as 'the' and 'and'",
+			Type:        pipescript.ConstArgType,
+			Optional:    true,
+			Schema: map[string]interface{}{
+				"type":    "boolean",
+				"default": false,
+			},
+		},
+	},
+	Constructor: pipescript.NewBasic(nil, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		if dp.Data == nil {
+			out.Data = nil
+			return out, nil
+		}
+		s, err := dp.String()
+		if err != nil {
+			return nil, err
+		}
+		words := tokenize(s, consts[0].(bool), consts[1].(bool))
+		res := make([]interface{}, len(words))
+		for i := range words {
+			res[i] = words[i]
+		}
+		out.Data = res
+		return out, nil
+	}),
+}
+
+var Ngrams = &pipescript.Transform{
+	Name:          "ngrams",
+	Description:   "Returns the sequences of n consecutive words of the text, each joined by spaces",
+	Documentation: string(resources.MustAsset("docs/transforms/ngrams.md")),
+	InputSchema:   textSchema,
+	OutputSchema: map[string]interface{}{
+		"type": "array",
+		"items": map[string]interface{}{
+			"type": "string",
+		},
+	},
+	Args: []pipescript.TransformArg{
+		{
+			Name:        "n",
+			Description: "The number of words in each sequence",
+			Type:        pipescript.ConstArgType,
+			Optional:    true,
+			Schema: map[string]interface{}{
+				"type":    "integer",
+				"minimum": 1,
+				"default": 2,
+			},
+		},
+	},
+	Constructor: pipescript.NewBasic(nil, func(dp *pipescript.Datapoint, args []*pipescript.Datapoint, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		if dp.Data == nil {
+			out.Data = nil
+			return out, nil
+		}
+		words, err := textTokens(dp.Data)
+		if err != nil {
+			return nil, err
+		}
+		n := int(consts[0].(int64))
+		res := make([]interface{}, 0)
+		for i := 0; i+n <= len(words); i++ {
+			res = append(res, strings.Join(words[i:i+n], " "))
+		}
+		out.Data = res
+		return out, nil
+	}),
+}
+
+var Wordfreq = &pipescript.Transform{
+	Name:          "wordfreq",
+	Description:   "Returns an object with the number of times that each word appears in the text of the datapoints",
+	Documentation: string(resources.MustAsset("docs/transforms/wordfreq.md")),
+	InputSchema:   textSchema,
+	OutputSchema: map[string]interface{}{
+		"type": "object",
+		"additionalProperties": map[string]interface{}{
+			"type": "integer",
+		},
+	},
+	Constructor: pipescript.NewAggregator(func(e *pipescript.TransformEnv, consts []interface{}, pipes []*pipescript.Pipe, out *pipescript.Datapoint) (*pipescript.Datapoint, error) {
+		dp, _, err := e.Next(nil)
+		if err != nil || dp == nil {
+			return nil, err
+		}
+		out.Timestamp = dp.Timestamp
+		counts := make(map[string]int64)
+		for dp != nil {
+			// Null values are skipped
+			if dp.Data != nil {
+				words, err := textTokens(dp.Data)
+				if err != nil {
+					return nil, err
+				}
+				for _, w := range words {
+					counts[w]++
+				}
+			}
+			out.Duration = dp.Timestamp + dp.Duration - out.Timestamp
+			dp, _, err = e.Next(nil)
+			if err != nil {
+				return nil, err
+			}
+		}
+		res := make(map[string]interface{}, len(counts))
+		for k, v := range counts {
+			res[k] = v
+		}
+		out.Data = res
+		return out, nil
+	}),
+}
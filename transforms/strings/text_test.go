@@ -0,0 +1,102 @@
+package strings
+
+import (
+	"testing"
+
+	"github.com/heedy/pipescript"
+)
+
+var textInput = []pipescript.Datapoint{
+	{Timestamp: 1, Data: "Don't   panic! The café’s coffee is GREAT."},
+	{Timestamp: 2, Data: "the coffee, the coffee"},
+	{Timestamp: 3, Data: nil},
+	{Timestamp: 4, Data: "Größe 42 naïve"},
+}
+
+func TestTokens(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "tokens",
+		Input:      textInput,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: []interface{}{"don't", "panic", "the", "café's", "coffee", "is", "great"}},
+			{Timestamp: 2, Data: []interface{}{"the", "coffee", "the", "coffee"}},
+			{Timestamp: 3, Data: nil},
+			{Timestamp: 4, Data: []interface{}{"größe", "42", "naïve"}},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "tokens(false, true)",
+		Input:      textInput,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: []interface{}{"panic", "café's", "coffee", "GREAT"}},
+			{Timestamp: 2, Data: []interface{}{"coffee", "coffee"}},
+			{Timestamp: 3, Data: nil},
+			{Timestamp: 4, Data: []interface{}{"Größe", "42", "naïve"}},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "tokens",
+		Input: []pipescript.Datapoint{
+			{Timestamp: 1, Data: 3},
+		},
+		OutputError: true,
+	}.Run(t)
+}
+
+func TestNgrams(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "ngrams(3)",
+		Input:      textInput,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: []interface{}{"don't panic the", "panic the café's", "the café's coffee", "café's coffee is", "coffee is great"}},
+			{Timestamp: 2, Data: []interface{}{"the coffee the", "coffee the coffee"}},
+			{Timestamp: 3, Data: nil},
+			{Timestamp: 4, Data: []interface{}{"größe 42 naïve"}},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "tokens(stopwords=true):ngrams",
+		Input:      textInput,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Data: []interface{}{"panic café's", "café's coffee", "coffee great"}},
+			{Timestamp: 2, Data: []interface{}{"coffee coffee"}},
+			{Timestamp: 3, Data: nil},
+			{Timestamp: 4, Data: []interface{}{"größe 42", "42 naïve"}},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "ngrams(0)",
+		Parsed:     "error",
+	}.Run(t)
+}
+
+func TestWordfreq(t *testing.T) {
+	Register()
+	pipescript.TestCase{
+		Pipescript: "tokens(stopwords=true):wordfreq",
+		Input:      textInput,
+		Output: []pipescript.Datapoint{
+			{Timestamp: 1, Duration: 3, Data: map[string]interface{}{
+				"panic":  int64(1),
+				"café's": int64(1),
+				"coffee": int64(3),
+				"great":  int64(1),
+				"größe":  int64(1),
+				"42":     int64(1),
+				"naïve":  int64(1),
+			}},
+		},
+	}.Run(t)
+	pipescript.TestCase{
+		Pipescript: "ngrams:wordfreq",
+		Input:      textInput[1:3],
+		Output: []pipescript.Datapoint{
+			{Timestamp: 2, Duration: 1, Data: map[string]interface{}{
+				"the coffee": int64(2),
+				"coffee the": int64(1),
+			}},
+		},
+	}.Run(t)
+}